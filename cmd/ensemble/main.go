@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"marboris/training"
+)
+
+func main() {
+	ensembleFile := flag.String("ensemble", training.EnsembleFile(), "ensemble to classify with")
+	top := flag.Int("top", 3, "number of predictions to print")
+	flag.Parse()
+
+	ensemble, err := training.LoadEnsemble(*ensembleFile)
+	if err != nil {
+		fmt.Println("Error loading the ensemble:", err)
+		os.Exit(1)
+	}
+
+	content := strings.Join(flag.Args(), " ")
+	fmt.Printf("Decision: %s\n", ensemble.Classify(content).Tag)
+
+	predictions := ensemble.Predict(content)
+	if *top < len(predictions) {
+		predictions = predictions[:*top]
+	}
+
+	for _, prediction := range predictions {
+		fmt.Printf("%.3f  %s\n", prediction.Score, prediction.Tag)
+	}
+}
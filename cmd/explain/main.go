@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"marboris/training"
+)
+
+func main() {
+	modelFile := flag.String("model", training.ModelFile(), "model bundle to explain")
+	asJSON := flag.Bool("json", false, "print the explanation as JSON")
+	nearest := flag.Int("nearest", 0, "also print the given number of nearest training patterns")
+	flag.Parse()
+
+	model, err := training.LoadModel(*modelFile)
+	if err != nil {
+		fmt.Println("Error loading the model:", err)
+		os.Exit(1)
+	}
+
+	content := strings.Join(flag.Args(), " ")
+	explanation := model.Explain(content)
+
+	var patterns []training.NearestPattern
+	if *nearest > 0 {
+		patterns = model.Nearest(content, *nearest)
+		if patterns == nil {
+			patterns = training.NearestPatterns(model.Locale, content, *nearest)
+		}
+	}
+
+	if *asJSON {
+		json.NewEncoder(os.Stdout).Encode(struct {
+			training.Explanation
+			Nearest []training.NearestPattern `json:"nearest,omitempty"`
+		}{explanation, patterns})
+		return
+	}
+
+	fmt.Print(explanation)
+	for _, pattern := range patterns {
+		fmt.Printf("%.3f  %-20s %s\n", pattern.Similarity, pattern.Tag, pattern.Pattern)
+	}
+}
@@ -28,11 +28,43 @@ const (
 	requireDef     = true
 	rateDef        = 0.1
 	hiddenNodesDef = 50
+	modelDef       = training.NetworkModel
+	fineTuneDef    = false
+	multiLabelDef  = false
+	votingDef      = training.AverageVoting
+	localeDef      = "en"
 )
 
-func longOperation(rate float64, hiddenNodes int) error {
-	fmt.Printf("Starting long operation with rate=%f and hiddenNodes=%d...\n", rate, hiddenNodes)
-	training.CreateNeuralNetwork("en", rate, hiddenNodes)
+// ensembleMembers returns a member per model type of the ensemble key, the
+// types being separated by "|", with the same hyperparameters and their own
+// seed.
+func ensembleMembers(value string, rate float64, hiddenNodes int) (members []training.EnsembleMember) {
+	for i, modelType := range strings.Split(value, "|") {
+		members = append(members, training.EnsembleMember{
+			Type:        strings.TrimSpace(modelType),
+			Rate:        rate,
+			HiddenNodes: hiddenNodes,
+			Seed:        int64(i + 1),
+		})
+	}
+
+	return
+}
+
+func longOperation(locale, model string, fineTune, multiLabel bool, rate float64, hiddenNodes int, ensemble, voting string) error {
+	fmt.Printf("Starting long operation with locale=%s, model=%s, rate=%f and hiddenNodes=%d...\n", locale, model, rate, hiddenNodes)
+
+	var err error
+	if ensemble != "" {
+		_, err = training.CreateEnsemble(locale, voting, ensembleMembers(ensemble, rate, hiddenNodes))
+	} else if fineTune {
+		_, _, err = training.FineTuneModel(training.ModelFile(), rate, multiLabel)
+	} else {
+		_, err = training.CreateModel(locale, model, rate, hiddenNodes, multiLabel)
+	}
+	if err != nil {
+		return err
+	}
 	fmt.Println("Operation completed.")
 
 	return nil
@@ -44,6 +76,12 @@ func handleRequest(conn net.Conn) {
 	req := requireDef
 	rate := rateDef
 	hiddenNodes := hiddenNodesDef
+	model := modelDef
+	fineTune := fineTuneDef
+	multiLabel := multiLabelDef
+	ensemble := ""
+	voting := votingDef
+	locale := localeDef
 
 	buf := make([]byte, 1024)
 	n, err := conn.Read(buf)
@@ -81,6 +119,28 @@ func handleRequest(conn net.Conn) {
 			if err != nil {
 				hiddenNodes = hiddenNodesDef
 			}
+		case "model":
+			model = value
+		case "finetune":
+			fineTune, err = strconv.ParseBool(value)
+			if err != nil {
+				fineTune = fineTuneDef
+			}
+		case "multilabel":
+			multiLabel, err = strconv.ParseBool(value)
+			if err != nil {
+				multiLabel = multiLabelDef
+			}
+		case "ensemble":
+			ensemble = value
+		case "voting":
+			voting = value
+		case "locale":
+			locale = value
+			if training.GetNameByTag(locale) == "" {
+				fmt.Printf("Unknown locale %q, using %q\n", locale, localeDef)
+				locale = localeDef
+			}
 		}
 	}
 
@@ -100,7 +160,7 @@ func handleRequest(conn net.Conn) {
 	var response string
 	if req {
 
-		err := longOperation(rate, hiddenNodes)
+		err := longOperation(locale, model, fineTune, multiLabel, rate, hiddenNodes, ensemble, voting)
 		if err != nil {
 			response = opFail
 		} else {
@@ -110,7 +170,7 @@ func handleRequest(conn net.Conn) {
 	} else {
 
 		go func() {
-			err := longOperation(rate, hiddenNodes)
+			err := longOperation(locale, model, fineTune, multiLabel, rate, hiddenNodes, ensemble, voting)
 			if err != nil {
 				fmt.Println("Background operation failed")
 			} else {
@@ -0,0 +1,68 @@
+package fuzzy
+
+// BKTree indexes words by their Damerau-Levenshtein distance, so that the
+// words close to another are found without comparing it to every word.
+type BKTree struct {
+	Word     string
+	Children map[int]*BKTree
+}
+
+func NewBKTree(words []string) (tree *BKTree) {
+	for _, word := range words {
+		if tree == nil {
+			tree = &BKTree{Word: word, Children: map[int]*BKTree{}}
+			continue
+		}
+
+		tree.Add(word)
+	}
+
+	return
+}
+
+func (tree *BKTree) Add(word string) {
+	for {
+		distance := DamerauLevenshtein(tree.Word, word)
+		if distance == 0 {
+			return
+		}
+
+		child, exists := tree.Children[distance]
+		if !exists {
+			tree.Children[distance] = &BKTree{Word: word, Children: map[int]*BKTree{}}
+			return
+		}
+
+		tree = child
+	}
+}
+
+// Closest returns the word of the tree nearest to word within maxDistance,
+// the first in alphabetical order when several are as near.
+func (tree *BKTree) Closest(word string, maxDistance int) (closest string, found bool) {
+	best := maxDistance + 1
+
+	nodes := []*BKTree{tree}
+	for len(nodes) > 0 {
+		node := nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		if node == nil {
+			continue
+		}
+
+		distance := DamerauLevenshtein(node.Word, word)
+		if distance < best || (distance == best && found && node.Word < closest) {
+			best = distance
+			closest = node.Word
+			found = true
+		}
+
+		for childDistance, child := range node.Children {
+			if childDistance >= distance-maxDistance && childDistance <= distance+maxDistance {
+				nodes = append(nodes, child)
+			}
+		}
+	}
+
+	return
+}
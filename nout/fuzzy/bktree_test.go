@@ -0,0 +1,34 @@
+package fuzzy
+
+import "testing"
+
+func TestBKTreeClosest(t *testing.T) {
+	tree := NewBKTree([]string{"book", "books", "cake", "boo", "cape", "cart", "book"})
+
+	for _, test := range []struct {
+		word        string
+		maxDistance int
+		closest     string
+	}{
+		{"books", 0, "books"},
+		// "boo" and "book" are as near, the first in alphabetical order wins
+		{"bok", 1, "boo"},
+		{"caqe", 1, "cake"},
+		{"acke", 1, "cake"},
+		{"cxrt", 1, "cart"},
+		{"cxxt", 1, ""},
+		{"xyz", 1, ""},
+		{"xyz", 4, "boo"},
+	} {
+		closest, found := tree.Closest(test.word, test.maxDistance)
+		if closest != test.closest || found != (test.closest != "") {
+			t.Errorf("%q within %d edits gave %q instead of %q", test.word, test.maxDistance, closest, test.closest)
+		}
+	}
+}
+
+func TestBKTreeEmpty(t *testing.T) {
+	if closest, found := NewBKTree(nil).Closest("book", 2); found {
+		t.Errorf("an empty tree gave %q", closest)
+	}
+}
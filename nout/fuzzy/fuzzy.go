@@ -0,0 +1,208 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Levenshtein returns the number of rune insertions, deletions and
+// substitutions turning first into second.
+func Levenshtein(first, second string) int {
+	a, b := []rune(first), []rune(second)
+
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// DamerauLevenshtein is Levenshtein where swapping two runes counts as one
+// edit, even when other edits happen between them.
+func DamerauLevenshtein(first, second string) int {
+	a, b := []rune(first), []rune(second)
+	infinity := len(a) + len(b)
+
+	distances := make([][]int, len(a)+2)
+	for i := range distances {
+		distances[i] = make([]int, len(b)+2)
+	}
+
+	distances[0][0] = infinity
+	for i := 0; i <= len(a); i++ {
+		distances[i+1][0] = infinity
+		distances[i+1][1] = i
+	}
+	for j := 0; j <= len(b); j++ {
+		distances[0][j+1] = infinity
+		distances[1][j+1] = j
+	}
+
+	lastRows := map[rune]int{}
+	for i := 1; i <= len(a); i++ {
+		lastColumn := 0
+		for j := 1; j <= len(b); j++ {
+			lastRow := lastRows[b[j-1]]
+			previousColumn := lastColumn
+
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+				lastColumn = j
+			}
+
+			distances[i+1][j+1] = min(
+				distances[i][j]+cost,
+				distances[i+1][j]+1,
+				distances[i][j+1]+1,
+				distances[lastRow][previousColumn]+(i-lastRow-1)+1+(j-previousColumn-1),
+			)
+		}
+
+		lastRows[a[i-1]] = i
+	}
+
+	return distances[len(a)+1][len(b)+1]
+}
+
+// Jaro returns the similarity of the strings between 0 and 1, from the runes
+// they share near the same position and how many of them are transposed.
+func Jaro(first, second string) float64 {
+	a, b := []rune(first), []rune(second)
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	window := max(max(len(a), len(b))/2-1, 0)
+
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	var matches float64
+	for i := range a {
+		for j := max(0, i-window); j < min(len(b), i+window+1); j++ {
+			if matchedB[j] || a[i] != b[j] {
+				continue
+			}
+
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	var transpositions float64
+	j := 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+
+		for !matchedB[j] {
+			j++
+		}
+
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	return (matches/float64(len(a)) + matches/float64(len(b)) + (matches-transpositions/2)/matches) / 3
+}
+
+// JaroWinkler raises the Jaro similarity of the strings sharing a prefix, of
+// four runes at most.
+func JaroWinkler(first, second string) float64 {
+	similarity := Jaro(first, second)
+
+	a, b := []rune(first), []rune(second)
+	prefix := 0
+	for prefix < min(len(a), len(b), 4) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	return similarity + float64(prefix)*0.1*(1-similarity)
+}
+
+// Similarity returns 1 minus the Levenshtein distance divided by the length
+// of the longest string, 1 being identical strings.
+func Similarity(first, second string) float64 {
+	length := max(len([]rune(first)), len([]rune(second)))
+	if length == 0 {
+		return 1
+	}
+
+	return 1 - float64(Levenshtein(first, second))/float64(length)
+}
+
+// Budget is the number of edits tolerated for a word: a quarter of its
+// length, maximum at most, so that short words must match exactly.
+func Budget(word string, maximum int) int {
+	return min(maximum, len([]rune(word))/4)
+}
+
+// Words splits the text on spaces and trims the punctuation around every
+// word.
+func Words(text string) (words []string) {
+	for _, field := range strings.Fields(text) {
+		word := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return
+}
+
+// PhraseDistance returns the smallest Damerau-Levenshtein distance between
+// the phrase and a run of as many words of the sentence, compared without
+// case, or -1 when the sentence is shorter than the phrase.
+func PhraseDistance(sentence, phrase string) int {
+	words := Words(strings.ToLower(sentence))
+	phrase = strings.Join(Words(strings.ToLower(phrase)), " ")
+	length := len(strings.Fields(phrase))
+
+	distance := -1
+	for i := 0; i+length <= len(words); i++ {
+		windowDistance := DamerauLevenshtein(strings.Join(words[i:i+length], " "), phrase)
+		if distance == -1 || windowDistance < distance {
+			distance = windowDistance
+		}
+	}
+
+	return distance
+}
+
+// ContainsPhrase tells whether the sentence contains the phrase within
+// maxDistance edits.
+func ContainsPhrase(sentence, phrase string, maxDistance int) bool {
+	distance := PhraseDistance(sentence, phrase)
+
+	return distance != -1 && distance <= maxDistance
+}
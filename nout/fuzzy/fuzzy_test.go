@@ -0,0 +1,103 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	for _, test := range []struct {
+		first, second string
+		distance      int
+	}{
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"ab", "ba", 2},
+		// The runes are compared, not the bytes
+		{"café", "cafe", 1},
+		{"naïve", "naive", 1},
+		{"کتاب", "کتب", 1},
+		{"日本語", "日本", 1},
+		{"😀a", "a", 1},
+	} {
+		if distance := Levenshtein(test.first, test.second); distance != test.distance {
+			t.Errorf("%q and %q are %d edits apart instead of %d", test.first, test.second, distance, test.distance)
+		}
+	}
+}
+
+func TestDamerauLevenshtein(t *testing.T) {
+	for _, test := range []struct {
+		first, second string
+		distance      int
+	}{
+		{"ab", "ba", 1},
+		{"haet", "heat", 1},
+		{"abcdef", "abcdfe", 1},
+		// A transposition with an insertion between the runes
+		{"ca", "abc", 2},
+		{"kitten", "sitting", 3},
+		{"", "ab", 2},
+		{"کتاب", "کاتب", 1},
+		{"été", "éte", 1},
+	} {
+		if distance := DamerauLevenshtein(test.first, test.second); distance != test.distance {
+			t.Errorf("%q and %q are %d edits apart instead of %d", test.first, test.second, distance, test.distance)
+		}
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	for _, test := range []struct {
+		first, second string
+		similarity    float64
+	}{
+		{"martha", "marhta", 0.9611},
+		{"dixon", "dicksonx", 0.8133},
+		{"dwayne", "duane", 0.84},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+		{"abc", "", 0},
+	} {
+		similarity := JaroWinkler(test.first, test.second)
+		if math.Abs(similarity-test.similarity) > 1e-3 {
+			t.Errorf("%q and %q are %.4f similar instead of %.4f", test.first, test.second, similarity, test.similarity)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	for _, test := range []struct {
+		first, second string
+		similarity    float64
+	}{
+		{"kitten", "sitting", 1 - 3.0/7},
+		{"café", "cafe", 0.75},
+		{"abc", "abc", 1},
+		{"", "", 1},
+		{"abc", "", 0},
+	} {
+		similarity := Similarity(test.first, test.second)
+		if math.Abs(similarity-test.similarity) > 1e-9 {
+			t.Errorf("%q and %q are %.4f similar instead of %.4f", test.first, test.second, similarity, test.similarity)
+		}
+	}
+}
+
+func TestBudget(t *testing.T) {
+	for _, test := range []struct {
+		word   string
+		budget int
+	}{
+		{"war", 0},
+		{"action", 1},
+		{"thriller", 2},
+		{"documentaries", 2},
+		{"ترسناک", 1},
+	} {
+		if budget := Budget(test.word, 2); budget != test.budget {
+			t.Errorf("%q has a budget of %d instead of %d", test.word, budget, test.budget)
+		}
+	}
+}
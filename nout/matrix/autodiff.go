@@ -0,0 +1,237 @@
+package network
+
+import (
+	"math"
+
+	util "marboris/nout/utils"
+)
+
+// NewTape returns an empty tape. Every operation records itself on the tape so
+// that Backward can propagate gradients from an output back to the leaves.
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+func (tape *Tape) Variable(value Matrix) *Variable {
+	return tape.record(value, nil)
+}
+
+func (tape *Tape) record(value Matrix, backward func(output *Variable)) *Variable {
+	variable := &Variable{
+		Value:    value,
+		Gradient: CreateMatrix(Rows(value), Columns(value)),
+	}
+
+	if backward != nil {
+		variable.backward = func() {
+			backward(variable)
+		}
+	}
+
+	tape.variables = append(tape.variables, variable)
+
+	return variable
+}
+
+func (tape *Tape) Backward(output *Variable) {
+	output.Gradient = ApplyFunction(CreateMatrix(Rows(output.Value), Columns(output.Value)), func(float64) float64 {
+		return 1
+	})
+
+	for i := len(tape.variables) - 1; i >= 0; i-- {
+		if tape.variables[i].backward != nil {
+			tape.variables[i].backward()
+		}
+	}
+}
+
+func accumulate(gradient, delta Matrix) {
+	for i := range delta {
+		for j := range delta[i] {
+			gradient[i][j] += delta[i][j]
+		}
+	}
+}
+
+func (tape *Tape) MatMul(a, b *Variable) *Variable {
+	return tape.record(DotProduct(a.Value, b.Value), func(output *Variable) {
+		accumulate(a.Gradient, DotProduct(output.Gradient, Transpose(b.Value)))
+		accumulate(b.Gradient, DotProduct(Transpose(a.Value), output.Gradient))
+	})
+}
+
+// Add sums two matrices of the same size, or adds b to every row of a when b
+// has a single row.
+func (tape *Tape) Add(a, b *Variable) *Variable {
+	value := ApplyFunctionWithIndex(CopyMatrix(a.Value), func(i, j int, x float64) float64 {
+		return x + b.Value[i%Rows(b.Value)][j]
+	})
+
+	return tape.record(value, func(output *Variable) {
+		accumulate(a.Gradient, output.Gradient)
+
+		for i := range output.Gradient {
+			for j, g := range output.Gradient[i] {
+				b.Gradient[i%Rows(b.Value)][j] += g
+			}
+		}
+	})
+}
+
+func (tape *Tape) Sub(a, b *Variable) *Variable {
+	return tape.Add(a, tape.Scale(b, -1))
+}
+
+func (tape *Tape) Mul(a, b *Variable) *Variable {
+	return tape.record(Multiplication(CopyMatrix(a.Value), b.Value), func(output *Variable) {
+		accumulate(a.Gradient, Multiplication(CopyMatrix(output.Gradient), b.Value))
+		accumulate(b.Gradient, Multiplication(CopyMatrix(output.Gradient), a.Value))
+	})
+}
+
+func (tape *Tape) Scale(a *Variable, rate float64) *Variable {
+	return tape.record(ApplyRate(CopyMatrix(a.Value), rate), func(output *Variable) {
+		accumulate(a.Gradient, ApplyRate(CopyMatrix(output.Gradient), rate))
+	})
+}
+
+// Apply maps fn over every element of a. derivative receives both the input
+// x and the output y of fn.
+func (tape *Tape) Apply(a *Variable, fn func(x float64) float64, derivative func(x, y float64) float64) *Variable {
+	return tape.record(ApplyFunction(CopyMatrix(a.Value), fn), func(output *Variable) {
+		accumulate(a.Gradient, ApplyFunctionWithIndex(CopyMatrix(output.Gradient), func(i, j int, g float64) float64 {
+			return g * derivative(a.Value[i][j], output.Value[i][j])
+		}))
+	})
+}
+
+func (tape *Tape) Sigmoid(a *Variable) *Variable {
+	return tape.Apply(a, util.Sigmoid, func(_, y float64) float64 {
+		return util.SigmoidDerivative(y)
+	})
+}
+
+func (tape *Tape) Tanh(a *Variable) *Variable {
+	return tape.Apply(a, util.Tanh, func(_, y float64) float64 {
+		return util.TanhDerivative(y)
+	})
+}
+
+func (tape *Tape) ReLU(a *Variable) *Variable {
+	return tape.Apply(a, util.ReLU, func(_, y float64) float64 {
+		return util.ReLUDerivative(y)
+	})
+}
+
+func (tape *Tape) OneMinus(a *Variable) *Variable {
+	return tape.Apply(a, func(x float64) float64 {
+		return 1 - x
+	}, func(_, _ float64) float64 {
+		return -1
+	})
+}
+
+func (tape *Tape) Exp(a *Variable) *Variable {
+	return tape.Apply(a, math.Exp, func(_, y float64) float64 {
+		return y
+	})
+}
+
+// Log clamps its input away from zero so that probabilities rounded to zero
+// do not produce infinities. The clamped inputs have no gradient.
+func (tape *Tape) Log(a *Variable) *Variable {
+	return tape.Apply(a, func(x float64) float64 {
+		return math.Log(math.Max(x, 1e-12))
+	}, func(x, _ float64) float64 {
+		if x < 1e-12 {
+			return 0
+		}
+
+		return 1 / x
+	})
+}
+
+// Softmax normalizes every row of a into a probability distribution.
+func (tape *Tape) Softmax(a *Variable) *Variable {
+	value := CopyMatrix(a.Value)
+
+	for _, row := range value {
+		highest := math.Inf(-1)
+		for _, x := range row {
+			highest = math.Max(highest, x)
+		}
+
+		var sum float64
+		for j, x := range row {
+			row[j] = math.Exp(x - highest)
+			sum += row[j]
+		}
+
+		for j := range row {
+			row[j] /= sum
+		}
+	}
+
+	return tape.record(value, func(output *Variable) {
+		for i, row := range output.Value {
+			var dot float64
+			for j, y := range row {
+				dot += y * output.Gradient[i][j]
+			}
+
+			for j, y := range row {
+				a.Gradient[i][j] += y * (output.Gradient[i][j] - dot)
+			}
+		}
+	})
+}
+
+func (tape *Tape) Sum(a *Variable) *Variable {
+	var sum float64
+	for _, row := range a.Value {
+		for _, x := range row {
+			sum += x
+		}
+	}
+
+	return tape.record(Matrix{{sum}}, func(output *Variable) {
+		accumulate(a.Gradient, ApplyFunction(CreateMatrix(Rows(a.Value), Columns(a.Value)), func(float64) float64 {
+			return output.Gradient[0][0]
+		}))
+	})
+}
+
+func (tape *Tape) Mean(a *Variable) *Variable {
+	return tape.Scale(tape.Sum(a), 1/float64(Rows(a.Value)*Columns(a.Value)))
+}
+
+// Gather returns the rows of a at the given indexes, which is how embedding
+// vectors are looked up.
+func (tape *Tape) Gather(a *Variable, indexes []int) *Variable {
+	value := make(Matrix, len(indexes))
+	for k, index := range indexes {
+		value[k] = append([]float64(nil), a.Value[index]...)
+	}
+
+	return tape.record(value, func(output *Variable) {
+		for k, index := range indexes {
+			for j, g := range output.Gradient[k] {
+				a.Gradient[index][j] += g
+			}
+		}
+	})
+}
+
+func (tape *Tape) SquaredError(output *Variable, target Matrix) *Variable {
+	difference := tape.Sub(output, tape.Variable(target))
+
+	return tape.Sum(tape.Mul(difference, difference))
+}
+
+// CrossEntropy applies a softmax to the logits and returns the cross entropy
+// with the one-hot target, averaged over the rows.
+func (tape *Tape) CrossEntropy(logits *Variable, target Matrix) *Variable {
+	likelihood := tape.Mul(tape.Variable(target), tape.Log(tape.Softmax(logits)))
+
+	return tape.Scale(tape.Sum(likelihood), -1/float64(Rows(target)))
+}
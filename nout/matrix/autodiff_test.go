@@ -0,0 +1,63 @@
+package network
+
+import (
+	"testing"
+)
+
+func checkTape(t *testing.T, name string, params []Matrix, fn func(tape *Tape, params []*Variable) *Variable) {
+	t.Helper()
+
+	for i, maxError := range GradientCheckTape(params, fn, 1e-5) {
+		if maxError > maxGradientError {
+			t.Errorf("%s: parameter %d has a relative error of %g", name, i, maxError)
+		}
+	}
+}
+
+func TestTapeGradientCheck(t *testing.T) {
+	target := Matrix{{0, 1, 0}, {1, 0, 0}, {0, 0, 1}, {0, 1, 0}}
+
+	checkTape(t, "dense sigmoid", []Matrix{RandomMatrix(4, 5), RandomMatrix(5, 3), RandomMatrix(1, 3)},
+		func(tape *Tape, params []*Variable) *Variable {
+			output := tape.Sigmoid(tape.Add(tape.MatMul(params[0], params[1]), params[2]))
+
+			return tape.SquaredError(output, target)
+		},
+	)
+
+	checkTape(t, "softmax log", []Matrix{RandomMatrix(4, 5), RandomMatrix(5, 3)},
+		func(tape *Tape, params []*Variable) *Variable {
+			probabilities := tape.Softmax(tape.MatMul(params[0], params[1]))
+
+			return tape.Sum(tape.Mul(tape.Variable(target), tape.Log(probabilities)))
+		},
+	)
+
+	checkTape(t, "cross entropy", []Matrix{RandomMatrix(4, 5), RandomMatrix(5, 3), RandomMatrix(1, 3)},
+		func(tape *Tape, params []*Variable) *Variable {
+			hidden := tape.Tanh(tape.MatMul(params[0], params[1]))
+
+			return tape.CrossEntropy(tape.Add(hidden, params[2]), target)
+		},
+	)
+
+	checkTape(t, "gather", []Matrix{RandomMatrix(6, 3), RandomMatrix(3, 2)},
+		func(tape *Tape, params []*Variable) *Variable {
+			return tape.Mean(tape.Sigmoid(tape.MatMul(tape.Gather(params[0], []int{4, 1, 4}), params[1])))
+		},
+	)
+}
+
+// TestTapeLogClamp checks that the inputs clamped by Log, whose output is a
+// constant, get no gradient.
+func TestTapeLogClamp(t *testing.T) {
+	tape := NewTape()
+	input := tape.Variable(Matrix{{0, 1e-20, 0.5}})
+	tape.Backward(tape.Sum(tape.Log(input)))
+
+	for j, expected := range []float64{0, 0, 2} {
+		if input.Gradient[0][j] != expected {
+			t.Errorf("the gradient of log(%g) is %g instead of %g", input.Value[0][j], input.Gradient[0][j], expected)
+		}
+	}
+}
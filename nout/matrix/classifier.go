@@ -0,0 +1,201 @@
+package network
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+)
+
+// saveJSON writes the classifier as JSON, which loadJSON reads back.
+func saveJSON(fileName string, classifier Classifier) error {
+	outF, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o777) // 0666 for windows support TODO()
+	if err != nil {
+		return err
+	}
+	defer outF.Close()
+
+	return json.NewEncoder(outF).Encode(classifier)
+}
+
+func loadJSON(fileName string, classifier Classifier) error {
+	inF, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer inF.Close()
+
+	return json.NewDecoder(inF).Decode(classifier)
+}
+
+func softmax(logits []float64) []float64 {
+	highest := math.Inf(-1)
+	for _, logit := range logits {
+		highest = math.Max(highest, logit)
+	}
+
+	var sum float64
+	probabilities := make([]float64, len(logits))
+	for i, logit := range logits {
+		probabilities[i] = math.Exp(logit - highest)
+		sum += probabilities[i]
+	}
+
+	for i := range probabilities {
+		probabilities[i] /= sum
+	}
+
+	return probabilities
+}
+
+func NewNetworkClassifier(locale string, rate float64, hiddenNodes, iterations int) *NetworkClassifier {
+	return &NetworkClassifier{
+		Locale:      locale,
+		Rate:        rate,
+		HiddenNodes: hiddenNodes,
+		Iterations:  iterations,
+	}
+}
+
+func (classifier *NetworkClassifier) Train(inputs, outputs Matrix) {
+	classifier.Network = CreateNetworkFrom(
+		classifier.Random, classifier.Locale, classifier.Rate, inputs, outputs, classifier.HiddenNodes,
+	)
+	if classifier.SampleWeights != nil {
+		classifier.Network.SetSampleWeights(classifier.SampleWeights)
+	}
+
+	classifier.Network.Train(classifier.Iterations)
+}
+
+func (classifier *NetworkClassifier) Predict(input []float64) []float64 {
+	return classifier.Network.Predict(input)
+}
+
+func (classifier *NetworkClassifier) Save(fileName string) error {
+	return saveJSON(fileName, classifier)
+}
+
+func (classifier *NetworkClassifier) Load(fileName string) error {
+	return loadJSON(fileName, classifier)
+}
+
+func NewNaiveBayes(smoothing float64) *NaiveBayes {
+	return &NaiveBayes{Smoothing: smoothing}
+}
+
+// Train counts the occurrences of every feature per class, as a multinomial
+// naive Bayes, and keeps the logarithms of the smoothed probabilities. Every
+// row counts as many times as its weight in SampleWeights.
+func (classifier *NaiveBayes) Train(inputs, outputs Matrix) {
+	classes, features := Columns(outputs), Columns(inputs)
+
+	var total float64
+	classCounts := make([]float64, classes)
+	featureCounts := CreateMatrix(classes, features)
+	for i, output := range outputs {
+		weight := 1.0
+		if classifier.SampleWeights != nil {
+			weight = classifier.SampleWeights[i]
+		}
+		total += weight
+
+		for c, y := range output {
+			classCounts[c] += weight * y
+
+			for j, x := range inputs[i] {
+				featureCounts[c][j] += weight * y * x
+			}
+		}
+	}
+
+	classifier.Priors = make([]float64, classes)
+	classifier.Likelihoods = CreateMatrix(classes, features)
+	for c := range classCounts {
+		classifier.Priors[c] = math.Log((classCounts[c] + classifier.Smoothing) / (total + classifier.Smoothing*float64(classes)))
+
+		var classTotal float64
+		for _, count := range featureCounts[c] {
+			classTotal += count
+		}
+
+		for j, count := range featureCounts[c] {
+			classifier.Likelihoods[c][j] = math.Log((count + classifier.Smoothing) / (classTotal + classifier.Smoothing*float64(features)))
+		}
+	}
+}
+
+func (classifier *NaiveBayes) Predict(input []float64) []float64 {
+	logits := make([]float64, len(classifier.Priors))
+
+	for c, prior := range classifier.Priors {
+		logits[c] = prior
+		for j, x := range input {
+			logits[c] += x * classifier.Likelihoods[c][j]
+		}
+	}
+
+	return softmax(logits)
+}
+
+func (classifier *NaiveBayes) Save(fileName string) error {
+	return saveJSON(fileName, classifier)
+}
+
+func (classifier *NaiveBayes) Load(fileName string) error {
+	return loadJSON(fileName, classifier)
+}
+
+func NewLogisticRegression(rate float64, iterations int, regularization float64) *LogisticRegression {
+	return &LogisticRegression{
+		Rate:           rate,
+		Iterations:     iterations,
+		Regularization: regularization,
+	}
+}
+
+// Train fits a multinomial logistic regression by gradient descent on the
+// mean cross entropy, every row weighted by SampleWeights, with an L2 penalty
+// on the weights.
+func (classifier *LogisticRegression) Train(inputs, outputs Matrix) {
+	classifier.Weights = CreateMatrix(Columns(inputs), Columns(outputs))
+	classifier.Biases = CreateMatrix(1, Columns(outputs))
+
+	// Scaling the targets of a row scales its cross entropy.
+	if classifier.SampleWeights != nil {
+		outputs = ApplyFunctionWithIndex(CopyMatrix(outputs), func(i, _ int, y float64) float64 {
+			return classifier.SampleWeights[i] * y
+		})
+	}
+
+	for i := 0; i < classifier.Iterations; i++ {
+		tape := NewTape()
+		weights, biases := tape.Variable(classifier.Weights), tape.Variable(classifier.Biases)
+
+		logits := tape.Add(tape.MatMul(tape.Variable(inputs), weights), biases)
+		loss := tape.Add(
+			tape.CrossEntropy(logits, outputs),
+			tape.Scale(tape.Sum(tape.Mul(weights, weights)), classifier.Regularization),
+		)
+		tape.Backward(loss)
+
+		classifier.Weights = Differencen(classifier.Weights, ApplyRate(weights.Gradient, classifier.Rate))
+		classifier.Biases = Differencen(classifier.Biases, ApplyRate(biases.Gradient, classifier.Rate))
+	}
+}
+
+func (classifier *LogisticRegression) Predict(input []float64) []float64 {
+	logits := DotProduct(Matrix{input}, classifier.Weights)[0]
+	for j := range logits {
+		logits[j] += classifier.Biases[0][j]
+	}
+
+	return softmax(logits)
+}
+
+func (classifier *LogisticRegression) Save(fileName string) error {
+	return saveJSON(fileName, classifier)
+}
+
+func (classifier *LogisticRegression) Load(fileName string) error {
+	return loadJSON(fileName, classifier)
+}
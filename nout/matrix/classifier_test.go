@@ -0,0 +1,82 @@
+package network
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestSampleWeights checks that weighting a row by 2 is the same as training
+// on it twice.
+func TestSampleWeights(t *testing.T) {
+	inputs := Matrix{{1, 0, 1}, {0, 1, 1}, {1, 1, 0}}
+	outputs := Matrix{{1, 0}, {0, 1}, {0, 1}}
+	repeatedInputs := append(CopyMatrix(inputs), inputs[0])
+	repeatedOutputs := append(CopyMatrix(outputs), outputs[0])
+	weights := []float64{2, 1, 1}
+
+	for name, classifiers := range map[string][2]Classifier{
+		"naive bayes": {
+			&NaiveBayes{Smoothing: 1, SampleWeights: weights},
+			&NaiveBayes{Smoothing: 1},
+		},
+		"logistic": {
+			&LogisticRegression{Rate: 0.5, Iterations: 50, SampleWeights: []float64{1.5, 0.75, 0.75}},
+			&LogisticRegression{Rate: 0.5, Iterations: 50},
+		},
+	} {
+		classifiers[0].Train(inputs, outputs)
+		classifiers[1].Train(repeatedInputs, repeatedOutputs)
+
+		for _, input := range inputs {
+			weighted, repeated := classifiers[0].Predict(input), classifiers[1].Predict(input)
+			for j := range weighted {
+				if math.Abs(weighted[j]-repeated[j]) > 1e-9 {
+					t.Errorf("%s: %v gives %v with weights and %v with a repeated row", name, input, weighted, repeated)
+				}
+			}
+		}
+	}
+}
+
+func TestKNNSampleWeights(t *testing.T) {
+	classifier := &KNN{K: 3, SampleWeights: []float64{3, 1, 1}}
+	classifier.Train(Matrix{{1, 0}, {1, 0.1}, {1, -0.1}}, Matrix{{1, 0}, {0, 1}, {0, 1}})
+
+	if scores := classifier.Predict([]float64{1, 0}); scores[0] <= scores[1] {
+		t.Errorf("the neighbor weighing 3 was outvoted: %v", scores)
+	}
+}
+
+func TestClassifierSaveLoad(t *testing.T) {
+	inputs := Matrix{{1, 0, 1}, {0, 1, 1}, {1, 1, 0}}
+	outputs := Matrix{{1, 0}, {0, 1}, {0, 1}}
+
+	for name, classifiers := range map[string][2]Classifier{
+		"network":     {NewNetworkClassifier("en", 0.1, 4, 10), &NetworkClassifier{}},
+		"naive bayes": {NewNaiveBayes(1), &NaiveBayes{}},
+		"logistic":    {NewLogisticRegression(0.5, 10, 0), &LogisticRegression{}},
+		"knn":         {NewKNN(2), &KNN{}},
+	} {
+		fileName := filepath.Join(t.TempDir(), "classifier.json")
+		classifiers[0].Train(inputs, outputs)
+		if err := classifiers[0].Save(fileName); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		if err := classifiers[1].Load(fileName); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		for _, input := range inputs {
+			if saved, loaded := classifiers[0].Predict(input), classifiers[1].Predict(input); !reflect.DeepEqual(saved, loaded) {
+				t.Errorf("%s: %v gives %v before saving and %v after loading", name, input, saved, loaded)
+			}
+		}
+	}
+
+	if err := NewKNN(1).Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("a missing file was loaded")
+	}
+}
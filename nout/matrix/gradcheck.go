@@ -0,0 +1,133 @@
+package network
+
+import (
+	"math"
+)
+
+// Loss is the squared error the hand-written backpropagation differentiates,
+// summed over every output of every row and weighted by SampleWeights.
+func (network Network) Loss() float64 {
+	network.FeedForward()
+	lastLayer := network.Layers[len(network.Layers)-1]
+
+	var sum float64
+	for i, row := range Differencen(network.Output, lastLayer) {
+		weight := 1.0
+		if network.SampleWeights != nil {
+			weight = network.SampleWeights[i]
+		}
+
+		for _, e := range row {
+			sum += weight * e * e
+		}
+	}
+
+	return sum
+}
+
+// GradientCheck compares the gradients computed by ComputeAllDerivatives with
+// central finite differences of Loss for every weight and bias, and returns
+// the maximum relative error of each layer.
+func (network Network) GradientCheck(epsilon float64) (checks []GradientCheck) {
+	analytic := network.Copy()
+	analytic.FeedForward()
+	derivatives := analytic.ComputeAllDerivatives()
+
+	numeric := network.Copy()
+	for i, derivative := range derivatives {
+		l := len(derivatives) - 1 - i
+
+		checks = append([]GradientCheck{{
+			Layer:        l,
+			WeightsError: numeric.maxRelativeError(numeric.Weights[l], derivative.Adjustment, epsilon),
+			BiasesError:  numeric.maxRelativeError(numeric.Biases[l], derivative.Delta, epsilon),
+		}}, checks...)
+	}
+
+	return
+}
+
+// RandomGradientCheck runs GradientCheck on a small network with random
+// inputs, outputs and weights.
+func RandomGradientCheck(samples, inputs, outputs int, hiddensNodes ...int) []GradientCheck {
+	input := RandomMatrix(samples, inputs)
+	output := ApplyFunction(RandomMatrix(samples, outputs), func(x float64) float64 {
+		return (x + 1) / 2
+	})
+
+	network := CreateNetwork("en", 0.1, input, output, hiddensNodes...)
+
+	return network.GradientCheck(1e-5)
+}
+
+// maxRelativeError perturbs each entry of parameters in place. The derivatives
+// applied by Adjust point down the loss, so the analytic gradient is their
+// negation.
+func (network Network) maxRelativeError(parameters, adjustment Matrix, epsilon float64) (maxError float64) {
+	for i := range parameters {
+		for j := range parameters[i] {
+			value := parameters[i][j]
+
+			parameters[i][j] = value + epsilon
+			plus := network.Loss()
+			parameters[i][j] = value - epsilon
+			minus := network.Loss()
+			parameters[i][j] = value
+
+			maxError = math.Max(maxError, RelativeError(-adjustment[i][j], (plus-minus)/(2*epsilon)))
+		}
+	}
+
+	return
+}
+
+// RelativeError compares gradients relatively to their magnitude, down to
+// 1e-5 where the rounding of finite differences would dominate.
+func RelativeError(analytic, numeric float64) float64 {
+	denominator := math.Max(math.Abs(analytic)+math.Abs(numeric), 1e-5)
+
+	return math.Abs(analytic-numeric) / denominator
+}
+
+// GradientCheckTape compares the gradients the tape computes for params with
+// central finite differences of fn, which must return a 1x1 variable, and
+// returns the maximum relative error of each parameter.
+func GradientCheckTape(params []Matrix, fn func(tape *Tape, params []*Variable) *Variable, epsilon float64) (errors []float64) {
+	evaluate := func() float64 {
+		tape := NewTape()
+		variables := make([]*Variable, len(params))
+		for i, param := range params {
+			variables[i] = tape.Variable(param)
+		}
+
+		return fn(tape, variables).Value[0][0]
+	}
+
+	tape := NewTape()
+	variables := make([]*Variable, len(params))
+	for i, param := range params {
+		variables[i] = tape.Variable(param)
+	}
+	tape.Backward(fn(tape, variables))
+
+	for k, param := range params {
+		var maxError float64
+		for i := range param {
+			for j := range param[i] {
+				value := param[i][j]
+
+				param[i][j] = value + epsilon
+				plus := evaluate()
+				param[i][j] = value - epsilon
+				minus := evaluate()
+				param[i][j] = value
+
+				maxError = math.Max(maxError, RelativeError(variables[k].Gradient[i][j], (plus-minus)/(2*epsilon)))
+			}
+		}
+
+		errors = append(errors, maxError)
+	}
+
+	return
+}
@@ -0,0 +1,66 @@
+package network
+
+import (
+	"math"
+	"testing"
+)
+
+const maxGradientError = 1e-5
+
+func TestRandomGradientCheck(t *testing.T) {
+	shapes := []struct {
+		samples, inputs, outputs int
+		hiddensNodes             []int
+	}{
+		{1, 1, 1, nil},
+		{3, 4, 2, nil},
+		{4, 5, 3, []int{6}},
+		{6, 3, 4, []int{5, 4}},
+		{2, 8, 2, []int{3, 7, 2}},
+	}
+
+	for _, shape := range shapes {
+		checks := RandomGradientCheck(shape.samples, shape.inputs, shape.outputs, shape.hiddensNodes...)
+		if len(checks) != len(shape.hiddensNodes)+1 {
+			t.Fatalf("%+v: %d layers were checked", shape, len(checks))
+		}
+
+		for _, check := range checks {
+			if check.WeightsError > maxGradientError || check.BiasesError > maxGradientError {
+				t.Errorf(
+					"%+v: layer %d has a relative error of %g on its weights and %g on its biases",
+					shape, check.Layer, check.WeightsError, check.BiasesError,
+				)
+			}
+		}
+	}
+}
+
+// TestSaturatedSigmoidGradient uses an output of 0.9, where the derivative
+// formerly computed in place on the activations, (1-a)², is 0.01 instead of
+// a(1-a) = 0.09.
+func TestSaturatedSigmoidGradient(t *testing.T) {
+	network := CreateNetwork("en", 0.1, Matrix{{1}}, Matrix{{0.2}})
+	network.Weights[0] = Matrix{{2.1972245773}}
+	network.Biases[0] = Matrix{{0}, {0}}
+
+	network.FeedForward()
+	a := network.Layers[1][1][0]
+	delta := network.ComputeLastLayerDerivatives().Delta[1][0]
+	if expected := 2 * (0.2 - a) * a * (1 - a); math.Abs(delta-expected) > 1e-12 {
+		t.Fatalf("the delta of an output of %g is %g instead of %g", a, delta, expected)
+	}
+
+	if network.Layers[1][1][0] != a {
+		t.Fatalf("computing the derivatives changed the activation from %g to %g", a, network.Layers[1][1][0])
+	}
+
+	for _, check := range network.GradientCheck(1e-5) {
+		if check.WeightsError > maxGradientError || check.BiasesError > maxGradientError {
+			t.Errorf(
+				"layer %d has a relative error of %g on its weights and %g on its biases",
+				check.Layer, check.WeightsError, check.BiasesError,
+			)
+		}
+	}
+}
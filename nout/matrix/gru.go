@@ -0,0 +1,163 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/gookit/color"
+)
+
+func CreateGRU(locale string, rate float64, vocabulary, dimensions, hiddenNodes, classes int) GRU {
+	return CreateGRUFrom(nil, locale, rate, vocabulary, dimensions, hiddenNodes, classes)
+}
+
+// CreateGRUFrom is CreateGRU with the weights, and the order of the sequences
+// while training, drawn from random.
+func CreateGRUFrom(random *Random, locale string, rate float64, vocabulary, dimensions, hiddenNodes, classes int) GRU {
+	weights := func(rows, columns int) Matrix {
+		return ApplyRate(RandomMatrixFrom(random, rows, columns), 1/math.Sqrt(float64(rows)))
+	}
+
+	return GRU{
+		Embedding: weights(vocabulary, dimensions),
+		Wz:        weights(dimensions, hiddenNodes),
+		Uz:        weights(hiddenNodes, hiddenNodes),
+		Bz:        CreateMatrix(1, hiddenNodes),
+		Wr:        weights(dimensions, hiddenNodes),
+		Ur:        weights(hiddenNodes, hiddenNodes),
+		Br:        CreateMatrix(1, hiddenNodes),
+		Wh:        weights(dimensions, hiddenNodes),
+		Uh:        weights(hiddenNodes, hiddenNodes),
+		Bh:        CreateMatrix(1, hiddenNodes),
+		Wy:        weights(hiddenNodes, classes),
+		By:        CreateMatrix(1, classes),
+		Rate:      rate,
+		Locale:    locale,
+		random:    random,
+	}
+}
+
+func (gru *GRU) parameters() []*Matrix {
+	return []*Matrix{
+		&gru.Embedding,
+		&gru.Wz, &gru.Uz, &gru.Bz,
+		&gru.Wr, &gru.Ur, &gru.Br,
+		&gru.Wh, &gru.Uh, &gru.Bh,
+		&gru.Wy, &gru.By,
+	}
+}
+
+func (gru *GRU) Variables(tape *Tape) []*Variable {
+	parameters := gru.parameters()
+	variables := make([]*Variable, len(parameters))
+	for i, parameter := range parameters {
+		variables[i] = tape.Variable(*parameter)
+	}
+
+	return variables
+}
+
+// Forward records the whole sequence on the tape and returns the logits of
+// the last hidden state, so that Backward propagates through time. variables
+// are the parameters returned by Variables.
+func (gru *GRU) Forward(tape *Tape, variables []*Variable, ids []int) *Variable {
+	embedding := variables[0]
+	wz, uz, bz := variables[1], variables[2], variables[3]
+	wr, ur, br := variables[4], variables[5], variables[6]
+	wh, uh, bh := variables[7], variables[8], variables[9]
+	wy, by := variables[10], variables[11]
+
+	hidden := tape.Variable(CreateMatrix(1, Columns(gru.Uz)))
+	for _, id := range ids {
+		x := tape.Gather(embedding, []int{id})
+
+		update := tape.Sigmoid(tape.Add(tape.Add(tape.MatMul(x, wz), tape.MatMul(hidden, uz)), bz))
+		reset := tape.Sigmoid(tape.Add(tape.Add(tape.MatMul(x, wr), tape.MatMul(hidden, ur)), br))
+		candidate := tape.Tanh(tape.Add(
+			tape.Add(tape.MatMul(x, wh), tape.MatMul(tape.Mul(reset, hidden), uh)),
+			bh,
+		))
+
+		hidden = tape.Add(
+			tape.Mul(tape.OneMinus(update), hidden),
+			tape.Mul(update, candidate),
+		)
+	}
+
+	return tape.Add(tape.MatMul(hidden, wy), by)
+}
+
+func (gru GRU) Predict(ids []int) []float64 {
+	tape := NewTape()
+	logits := gru.Forward(tape, gru.Variables(tape), ids)
+
+	return tape.Softmax(logits).Value[0]
+}
+
+func (gru *GRU) step(ids []int, output []float64, weight float64) float64 {
+	tape := NewTape()
+	variables := gru.Variables(tape)
+	logits := gru.Forward(tape, variables, ids)
+	loss := tape.Scale(tape.CrossEntropy(logits, Matrix{output}), weight)
+	tape.Backward(loss)
+
+	for i, parameter := range gru.parameters() {
+		*parameter = Differencen(*parameter, ApplyRate(variables[i].Gradient, gru.Rate))
+	}
+
+	return loss.Value[0][0]
+}
+
+func (gru *GRU) Train(sequences [][]int, outputs Matrix, iterations int) {
+	start := time.Now()
+
+	bar := newTrainingBar(iterations)
+	bar.Start()
+
+	var loss float64
+	for i := 0; i < iterations; i++ {
+		loss = 0
+		for _, k := range gru.random.Perm(len(sequences)) {
+			weight := 1.0
+			if gru.SampleWeights != nil {
+				weight = gru.SampleWeights[k]
+			}
+
+			loss += gru.step(sequences[k], outputs[k], weight)
+		}
+		loss /= float64(len(sequences))
+
+		if iterations < 20 || i%(iterations/20) == 0 {
+			gru.Errors = append(gru.Errors, loss)
+		}
+
+		bar.Increment()
+	}
+
+	bar.Finish()
+
+	arrangedError := fmt.Sprintf("%.5f", loss)
+
+	elapsed := time.Since(start)
+
+	gru.Time = math.Floor(elapsed.Seconds()*100) / 100
+
+	fmt.Printf("The error rate is %s.\n", color.FgGreen.Render(arrangedError))
+}
+
+func (gru GRU) Save(fileName string) {
+	outF, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o777) // 0666 for windows support TODO()
+	if err != nil {
+		panic("Failed to save the network to " + fileName + ".")
+	}
+	defer outF.Close()
+
+	encoder := json.NewEncoder(outF)
+	err = encoder.Encode(gru)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,20 @@
+package network
+
+import "testing"
+
+// TestGRUGradientCheck checks the gradients propagated through time over a
+// short sequence, repeating a word so that its embedding gets two gradients.
+func TestGRUGradientCheck(t *testing.T) {
+	gru := CreateGRU("en", 0.1, 6, 4, 5, 3)
+	ids := []int{2, 0, 5, 2}
+	target := Matrix{{0, 0, 1}}
+
+	var params []Matrix
+	for _, parameter := range gru.parameters() {
+		params = append(params, *parameter)
+	}
+
+	checkTape(t, "gru", params, func(tape *Tape, variables []*Variable) *Variable {
+		return tape.CrossEntropy(gru.Forward(tape, variables, ids), target)
+	})
+}
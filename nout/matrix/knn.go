@@ -0,0 +1,92 @@
+package network
+
+import (
+	"math"
+	"sort"
+)
+
+func CosineSimilarity(vector, vector2 []float64) float64 {
+	var dot, norm, norm2 float64
+	for i := range vector {
+		dot += vector[i] * vector2[i]
+		norm += vector[i] * vector[i]
+		norm2 += vector2[i] * vector2[i]
+	}
+
+	if norm == 0 || norm2 == 0 {
+		return 0
+	}
+
+	return dot / math.Sqrt(norm*norm2)
+}
+
+func NewKNN(k int) *KNN {
+	return &KNN{K: k}
+}
+
+// Train only indexes the inputs and their outputs.
+func (classifier *KNN) Train(inputs, outputs Matrix) {
+	classifier.Vectors = CopyMatrix(inputs)
+	classifier.Labels = CopyMatrix(outputs)
+}
+
+// Nearest returns the k indexed vectors most similar to the input, the most
+// similar first.
+func (classifier *KNN) Nearest(input []float64, k int) []Neighbor {
+	neighbors := make([]Neighbor, len(classifier.Vectors))
+	for i, vector := range classifier.Vectors {
+		neighbors[i] = Neighbor{
+			Index:      i,
+			Similarity: CosineSimilarity(input, vector),
+		}
+	}
+
+	sort.SliceStable(neighbors, func(i, j int) bool {
+		return neighbors[i].Similarity > neighbors[j].Similarity
+	})
+
+	if k < len(neighbors) {
+		neighbors = neighbors[:k]
+	}
+
+	return neighbors
+}
+
+// Predict sums the outputs of the K nearest vectors weighted by their
+// similarity and their SampleWeights, so that the scores add up to 1 unless
+// nothing is similar.
+func (classifier *KNN) Predict(input []float64) []float64 {
+	scores := make([]float64, Columns(classifier.Labels))
+
+	var total float64
+	for _, neighbor := range classifier.Nearest(input, classifier.K) {
+		weight := neighbor.Similarity
+		if classifier.SampleWeights != nil {
+			weight *= classifier.SampleWeights[neighbor.Index]
+		}
+
+		for j, label := range classifier.Labels[neighbor.Index] {
+			scores[j] += weight * label
+		}
+
+		total += weight
+	}
+
+	if total == 0 {
+		return scores
+	}
+
+	for j := range scores {
+		scores[j] /= total
+	}
+
+	return scores
+}
+
+func (classifier *KNN) Save(fileName string) error {
+	return saveJSON(fileName, classifier)
+}
+
+func (classifier *KNN) Load(fileName string) error {
+	return loadJSON(fileName, classifier)
+}
@@ -0,0 +1,239 @@
+package network
+
+import (
+	"math/rand"
+
+	util "marboris/nout/utils"
+)
+
+func NewParameter(value Matrix) *Parameter {
+	return &Parameter{
+		Value:    value,
+		Gradient: CreateMatrix(Rows(value), Columns(value)),
+	}
+}
+
+func NewDense(inputs, outputs int) *Dense {
+	return NewDenseFrom(nil, inputs, outputs)
+}
+
+func NewDenseFrom(random *Random, inputs, outputs int) *Dense {
+	return &Dense{
+		Weights: NewParameter(RandomMatrixFrom(random, inputs, outputs)),
+		Biases:  NewParameter(RandomMatrixFrom(random, 1, outputs)),
+	}
+}
+
+func (dense *Dense) Forward(input Matrix, _ bool) Matrix {
+	dense.input = input
+
+	output := DotProduct(input, dense.Weights.Value)
+	return ApplyFunctionWithIndex(output, func(_, j int, x float64) float64 {
+		return x + dense.Biases.Value[0][j]
+	})
+}
+
+func (dense *Dense) Backward(gradient Matrix) Matrix {
+	dense.Weights.Gradient = DotProduct(Transpose(dense.input), gradient)
+
+	dense.Biases.Gradient = CreateMatrix(1, Columns(gradient))
+	for i := range gradient {
+		for j, g := range gradient[i] {
+			dense.Biases.Gradient[0][j] += g
+		}
+	}
+
+	return DotProduct(gradient, Transpose(dense.Weights.Value))
+}
+
+func (dense *Dense) Params() []*Parameter {
+	return []*Parameter{dense.Weights, dense.Biases}
+}
+
+func NewSigmoid() *Activation {
+	return &Activation{Function: util.Sigmoid, Derivative: util.SigmoidDerivative}
+}
+
+func NewTanh() *Activation {
+	return &Activation{Function: util.Tanh, Derivative: util.TanhDerivative}
+}
+
+func NewReLU() *Activation {
+	return &Activation{Function: util.ReLU, Derivative: util.ReLUDerivative}
+}
+
+func (activation *Activation) Forward(input Matrix, _ bool) Matrix {
+	activation.output = ApplyFunction(CopyMatrix(input), activation.Function)
+	return activation.output
+}
+
+// Backward expects Derivative to be expressed from the activation output, as
+// with util.SigmoidDerivative.
+func (activation *Activation) Backward(gradient Matrix) Matrix {
+	return ApplyFunctionWithIndex(CopyMatrix(gradient), func(i, j int, x float64) float64 {
+		return x * activation.Derivative(activation.output[i][j])
+	})
+}
+
+func (activation *Activation) Params() []*Parameter {
+	return nil
+}
+
+func NewDropout(rate float64) *Dropout {
+	return &Dropout{Rate: rate}
+}
+
+// Forward zeroes inputs with probability Rate while training and scales the
+// others up, so nothing changes at inference time.
+func (dropout *Dropout) Forward(input Matrix, training bool) Matrix {
+	if !training || dropout.Rate <= 0 {
+		dropout.mask = nil
+		return input
+	}
+
+	dropout.mask = ApplyFunction(CreateMatrix(Rows(input), Columns(input)), func(float64) float64 {
+		if rand.Float64() < dropout.Rate {
+			return 0
+		}
+
+		return 1 / (1 - dropout.Rate)
+	})
+
+	return Multiplication(CopyMatrix(input), dropout.mask)
+}
+
+func (dropout *Dropout) Backward(gradient Matrix) Matrix {
+	if dropout.mask == nil {
+		return gradient
+	}
+
+	return Multiplication(CopyMatrix(gradient), dropout.mask)
+}
+
+func (dropout *Dropout) Params() []*Parameter {
+	return nil
+}
+
+const (
+	MeanPooling = "mean"
+	SumPooling  = "sum"
+)
+
+func NewEmbedding(vocabulary, dimensions int) *Embedding {
+	return NewEmbeddingFrom(nil, vocabulary, dimensions)
+}
+
+func NewEmbeddingFrom(random *Random, vocabulary, dimensions int) *Embedding {
+	return &Embedding{
+		Vectors: NewParameter(RandomMatrixFrom(random, vocabulary, dimensions)),
+		Pooling: MeanPooling,
+	}
+}
+
+// SetVectors replaces the vector of every word of vocabulary found in vectors
+// and returns the rows that were replaced. Vectors of another dimension are
+// ignored.
+func (embedding *Embedding) SetVectors(vocabulary []string, vectors map[string][]float64) (rows []int) {
+	for i, word := range vocabulary {
+		vector, exists := vectors[word]
+		if !exists || len(vector) != Columns(embedding.Vectors.Value) {
+			continue
+		}
+
+		copy(embedding.Vectors.Value[i], vector)
+		rows = append(rows, i)
+	}
+
+	return
+}
+
+// Forward reads each input row as a sequence of vocabulary IDs, negative IDs
+// being padding, and returns the mean or the sum of their vectors depending
+// on Pooling.
+func (embedding *Embedding) Forward(input Matrix, _ bool) Matrix {
+	embedding.ids = input
+	output := CreateMatrix(Rows(input), Columns(embedding.Vectors.Value))
+
+	for i, ids := range input {
+		count := embedding.count(ids)
+
+		for _, id := range ids {
+			if id < 0 {
+				continue
+			}
+
+			for j, value := range embedding.Vectors.Value[int(id)] {
+				output[i][j] += value / count
+			}
+		}
+	}
+
+	return output
+}
+
+// Backward has no gradient to return since IDs are not differentiable.
+func (embedding *Embedding) Backward(gradient Matrix) Matrix {
+	embedding.Vectors.Gradient = CreateMatrix(Rows(embedding.Vectors.Value), Columns(embedding.Vectors.Value))
+
+	for i, ids := range embedding.ids {
+		count := embedding.count(ids)
+
+		for _, id := range ids {
+			if id < 0 {
+				continue
+			}
+
+			for j, g := range gradient[i] {
+				embedding.Vectors.Gradient[int(id)][j] += g / count
+			}
+		}
+	}
+
+	for row := range embedding.frozen {
+		for j := range embedding.Vectors.Gradient[row] {
+			embedding.Vectors.Gradient[row][j] = 0
+		}
+	}
+
+	return nil
+}
+
+func (embedding *Embedding) Params() []*Parameter {
+	return []*Parameter{embedding.Vectors}
+}
+
+// Freeze keeps the vectors of the rows, usually the pre-trained ones, from
+// being updated while the rest of the model trains, or every vector when no
+// row is given.
+func (embedding *Embedding) Freeze(rows ...int) {
+	if len(rows) == 0 {
+		embedding.Vectors.Frozen = true
+		return
+	}
+
+	if embedding.frozen == nil {
+		embedding.frozen = map[int]bool{}
+	}
+
+	for _, row := range rows {
+		embedding.frozen[row] = true
+	}
+}
+
+func (embedding *Embedding) count(ids []float64) (count float64) {
+	if embedding.Pooling == SumPooling {
+		return 1
+	}
+
+	for _, id := range ids {
+		if id >= 0 {
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 1
+	}
+
+	return
+}
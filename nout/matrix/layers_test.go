@@ -0,0 +1,33 @@
+package network
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEmbeddingFreezeRows(t *testing.T) {
+	embedding := NewEmbedding(3, 2)
+	rows := embedding.SetVectors([]string{"hello", "unknown", "world"}, map[string][]float64{
+		"hello": {0.5, -0.5},
+		"world": {1, 2, 3},
+		"other": {1, 1},
+	})
+	if !reflect.DeepEqual(rows, []int{0}) {
+		t.Fatalf("the rows %v were set instead of [0]", rows)
+	}
+	embedding.Freeze(rows...)
+
+	before := CopyMatrix(embedding.Vectors.Value)
+	model := NewSequential(0.5, embedding, NewDense(2, 1), NewSigmoid())
+	model.Train(Matrix{{0, 1, 2}, {1, 2, -1}}, Matrix{{1}, {0}}, 5)
+
+	if !reflect.DeepEqual(embedding.Vectors.Value[0], before[0]) {
+		t.Errorf("the frozen vector went from %v to %v", before[0], embedding.Vectors.Value[0])
+	}
+
+	for _, row := range []int{1, 2} {
+		if reflect.DeepEqual(embedding.Vectors.Value[row], before[row]) {
+			t.Errorf("the vector of row %d did not learn", row)
+		}
+	}
+}
@@ -1,9 +1,5 @@
 package network
 
-import (
-	"math/rand"
-)
-
 func CreateMatrix(rows, columns int) (matrix Matrix) {
 	matrix = make(Matrix, rows)
 
@@ -14,17 +10,32 @@ func CreateMatrix(rows, columns int) (matrix Matrix) {
 	return
 }
 
+func CopyMatrix(matrix Matrix) (resultMatrix Matrix) {
+	resultMatrix = make(Matrix, len(matrix))
+
+	for i := range matrix {
+		resultMatrix[i] = make([]float64, len(matrix[i]))
+		copy(resultMatrix[i], matrix[i])
+	}
+
+	return
+}
+
 func Columns(matrix Matrix) int {
 	return len(matrix[0])
 }
 
-func RandomMatrix(rows, columns int) (matrix Matrix) {
+func RandomMatrix(rows, columns int) Matrix {
+	return RandomMatrixFrom(nil, rows, columns)
+}
+
+func RandomMatrixFrom(random *Random, rows, columns int) (matrix Matrix) {
 	matrix = make(Matrix, rows)
 
 	for i := 0; i < rows; i++ {
 		matrix[i] = make([]float64, columns)
 		for j := 0; j < columns; j++ {
-			matrix[i][j] = rand.Float64()*2.0 - 1.0
+			matrix[i][j] = random.Float64()*2.0 - 1.0
 		}
 	}
 
@@ -36,6 +47,11 @@ func Rows(matrix Matrix) int {
 }
 
 func CreateNetwork(locale string, rate float64, input, output Matrix, hiddensNodes ...int) Network {
+	return CreateNetworkFrom(nil, locale, rate, input, output, hiddensNodes...)
+}
+
+// CreateNetworkFrom is CreateNetwork with the weights drawn from random.
+func CreateNetworkFrom(random *Random, locale string, rate float64, input, output Matrix, hiddensNodes ...int) Network {
 	input = append([][]float64{
 		make([]float64, len(input[0])),
 	}, input...)
@@ -59,8 +75,8 @@ func CreateNetwork(locale string, rate float64, input, output Matrix, hiddensNod
 	for i := 0; i < weightsNumber; i++ {
 		rows, columns := Columns(layers[i]), Columns(layers[i+1])
 
-		weights = append(weights, RandomMatrix(rows, columns))
-		biases = append(biases, RandomMatrix(Rows(layers[i]), columns))
+		weights = append(weights, RandomMatrixFrom(random, rows, columns))
+		biases = append(biases, RandomMatrixFrom(random, Rows(layers[i]), columns))
 	}
 
 	return Network{
@@ -28,15 +28,18 @@ func (network Network) Adjust(derivatives []Derivative) {
 	}
 }
 
-func (network *Network) FeedBackward() {
-	var derivatives []Derivative
+func (network *Network) ComputeAllDerivatives() (derivatives []Derivative) {
 	derivatives = append(derivatives, network.ComputeLastLayerDerivatives())
 
 	for i := 0; i < len(network.Layers)-2; i++ {
 		derivatives = append(derivatives, network.ComputeDerivatives(i, derivatives))
 	}
 
-	network.Adjust(derivatives)
+	return
+}
+
+func (network *Network) FeedBackward() {
+	network.Adjust(network.ComputeAllDerivatives())
 }
 
 func (network *Network) ComputeError() float64 {
@@ -59,22 +62,14 @@ func (network *Network) ComputeError() float64 {
 func (network *Network) Train(iterations int) {
 	start := time.Now()
 
-	bar := pb.New(iterations).Postfix(fmt.Sprintf(
-		" - %s %s %s",
-		color.FgBlue.Render("Training the"),
-		color.FgRed.Render("english"), // locales.GetNameByTag(network.Locale)
-		color.FgBlue.Render("neural network"),
-	))
-	bar.Format("(██░)")
-	bar.SetMaxWidth(60)
-	bar.ShowCounters = false
+	bar := newTrainingBar(iterations)
 	bar.Start()
 
 	for i := 0; i < iterations; i++ {
 		network.FeedForward()
 		network.FeedBackward()
 
-		if i%(iterations/20) == 0 {
+		if iterations < 20 || i%(iterations/20) == 0 {
 			network.Errors = append(
 				network.Errors,
 
@@ -96,12 +91,26 @@ func (network *Network) Train(iterations int) {
 	fmt.Printf("The error rate is %s.\n", color.FgGreen.Render(arrangedError))
 }
 
+func newTrainingBar(iterations int) *pb.ProgressBar {
+	bar := pb.New(iterations).Postfix(fmt.Sprintf(
+		" - %s %s %s",
+		color.FgBlue.Render("Training the"),
+		color.FgRed.Render("english"), // locales.GetNameByTag(network.Locale)
+		color.FgBlue.Render("neural network"),
+	))
+	bar.Format("(██░)")
+	bar.SetMaxWidth(60)
+	bar.ShowCounters = false
+
+	return bar
+}
+
 func (network Network) ComputeLastLayerDerivatives() Derivative {
 	l := len(network.Layers) - 1
 	lastLayer := network.Layers[l]
 
-	cost := Differencen(network.Output, lastLayer)
-	sigmoidDerivative := Multiplication(lastLayer, ApplyFunction(lastLayer, util.SubtractsOne))
+	cost := network.weightRows(Differencen(network.Output, lastLayer))
+	sigmoidDerivative := ApplyFunction(CopyMatrix(lastLayer), util.SigmoidDerivative)
 
 	delta := Multiplication(
 		ApplyFunction(cost, util.MultipliesByTwo),
@@ -115,6 +124,22 @@ func (network Network) ComputeLastLayerDerivatives() Derivative {
 	}
 }
 
+// SetSampleWeights weights the error of every input row given to
+// CreateNetwork, the empty first row keeping a weight of 1.
+func (network *Network) SetSampleWeights(weights []float64) {
+	network.SampleWeights = append([]float64{1}, weights...)
+}
+
+func (network Network) weightRows(matrix Matrix) Matrix {
+	if network.SampleWeights == nil {
+		return matrix
+	}
+
+	return ApplyFunctionWithIndex(matrix, func(i, _ int, x float64) float64 {
+		return network.SampleWeights[i] * x
+	})
+}
+
 func (network Network) ComputeDerivatives(i int, derivatives []Derivative) Derivative {
 	l := len(network.Layers) - 2 - i
 
@@ -123,10 +148,7 @@ func (network Network) ComputeDerivatives(i int, derivatives []Derivative) Deriv
 			derivatives[i].Delta,
 			Transpose(network.Weights[l]),
 		),
-		Multiplication(
-			network.Layers[l],
-			ApplyFunction(network.Layers[l], util.SubtractsOne),
-		),
+		ApplyFunction(CopyMatrix(network.Layers[l]), util.SigmoidDerivative),
 	)
 	weights := DotProduct(Transpose(network.Layers[l-1]), delta)
 
@@ -148,6 +170,39 @@ func (network *Network) FeedForward() {
 	}
 }
 
+// Predict feeds a single input through the network without changing its
+// layers. Like the first row of Layers, it uses the first row of every bias.
+func (network Network) Predict(input []float64) []float64 {
+	layer := Matrix{input}
+
+	for i := range network.Weights {
+		layer = ApplyFunctionWithIndex(DotProduct(layer, network.Weights[i]), func(_, j int, x float64) float64 {
+			return util.Sigmoid(x + network.Biases[i][0][j])
+		})
+	}
+
+	return layer[0]
+}
+
+func (network Network) Copy() Network {
+	copied := network
+	copied.Layers = copyMatrices(network.Layers)
+	copied.Weights = copyMatrices(network.Weights)
+	copied.Biases = copyMatrices(network.Biases)
+	copied.Output = CopyMatrix(network.Output)
+	copied.Errors = append([]float64(nil), network.Errors...)
+
+	return copied
+}
+
+func copyMatrices(matrices []Matrix) (copied []Matrix) {
+	for _, matrix := range matrices {
+		copied = append(copied, CopyMatrix(matrix))
+	}
+
+	return
+}
+
 func (network Network) Save(fileName string) {
 	outF, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR, 0o777) // 0666 for windows support TODO()
 	if err != nil {
@@ -0,0 +1,38 @@
+package network
+
+import "math/rand"
+
+// Random is the source of the random numbers of a model. A nil Random draws
+// from the global source of math/rand, so that only seeded models have their
+// own.
+type Random struct {
+	source *rand.Rand
+}
+
+func NewRandom(seed int64) *Random {
+	return &Random{source: rand.New(rand.NewSource(seed))}
+}
+
+func (random *Random) Float64() float64 {
+	if random == nil {
+		return rand.Float64()
+	}
+
+	return random.source.Float64()
+}
+
+func (random *Random) Intn(n int) int {
+	if random == nil {
+		return rand.Intn(n)
+	}
+
+	return random.source.Intn(n)
+}
+
+func (random *Random) Perm(n int) []int {
+	if random == nil {
+		return rand.Perm(n)
+	}
+
+	return random.source.Perm(n)
+}
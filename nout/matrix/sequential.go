@@ -0,0 +1,178 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/gookit/color"
+)
+
+func NewSequential(rate float64, layers ...Layer) *Sequential {
+	return &Sequential{
+		Layers: layers,
+		Rate:   rate,
+	}
+}
+
+func (model *Sequential) Forward(input Matrix, training bool) Matrix {
+	for _, layer := range model.Layers {
+		input = layer.Forward(input, training)
+	}
+
+	return input
+}
+
+func (model *Sequential) Backward(gradient Matrix) Matrix {
+	for i := len(model.Layers) - 1; i >= 0; i-- {
+		gradient = model.Layers[i].Backward(gradient)
+	}
+
+	return gradient
+}
+
+func (model *Sequential) Params() (params []*Parameter) {
+	for _, layer := range model.Layers {
+		params = append(params, layer.Params()...)
+	}
+
+	return
+}
+
+func (model *Sequential) Predict(input []float64) []float64 {
+	return model.Forward(Matrix{input}, false)[0]
+}
+
+// Loss returns the squared error of the predictions and its gradient, every
+// row being weighted by SampleWeights when there is one weight per row.
+func (model *Sequential) Loss(output, target Matrix) (float64, Matrix) {
+	var sum float64
+
+	gradient := ApplyFunctionWithIndex(CopyMatrix(output), func(i, j int, x float64) float64 {
+		weight := 1.0
+		if len(model.SampleWeights) == len(target) {
+			weight = model.SampleWeights[i]
+		}
+
+		e := x - target[i][j]
+		sum += weight * e * e
+
+		return 2 * weight * e
+	})
+
+	return sum, gradient
+}
+
+func (model *Sequential) Step() {
+	for _, param := range model.Params() {
+		if param.Frozen {
+			continue
+		}
+
+		param.Value = Differencen(param.Value, ApplyRate(param.Gradient, model.Rate))
+	}
+}
+
+func (model *Sequential) Train(inputs, outputs Matrix, iterations int) {
+	for i := 0; i < iterations; i++ {
+		loss, gradient := model.Loss(model.Forward(inputs, true), outputs)
+		model.Backward(gradient)
+		model.Step()
+
+		if iterations < 20 || i%(iterations/20) == 0 {
+			model.Errors = append(model.Errors, loss/float64(len(outputs)))
+		}
+	}
+
+	loss, _ := model.Loss(model.Forward(inputs, false), outputs)
+	arrangedError := fmt.Sprintf("%.5f", loss/float64(len(outputs)))
+
+	fmt.Printf("The error rate is %s.\n", color.FgGreen.Render(arrangedError))
+}
+
+// GradientCheck compares the gradients of every parameter with central finite
+// differences of Loss, with dropout disabled, and returns the maximum relative
+// error of each parameter.
+func (model *Sequential) GradientCheck(inputs, outputs Matrix, epsilon float64) (errors []float64) {
+	_, gradient := model.Loss(model.Forward(inputs, false), outputs)
+	model.Backward(gradient)
+
+	for _, param := range model.Params() {
+		analytic := CopyMatrix(param.Gradient)
+
+		var maxError float64
+		for i := range param.Value {
+			for j := range param.Value[i] {
+				value := param.Value[i][j]
+
+				param.Value[i][j] = value + epsilon
+				plus, _ := model.Loss(model.Forward(inputs, false), outputs)
+				param.Value[i][j] = value - epsilon
+				minus, _ := model.Loss(model.Forward(inputs, false), outputs)
+				param.Value[i][j] = value
+
+				maxError = math.Max(maxError, RelativeError(analytic[i][j], (plus-minus)/(2*epsilon)))
+			}
+		}
+
+		errors = append(errors, maxError)
+	}
+
+	return
+}
+
+// Values returns the values of every parameter, which SetValues restores on a
+// model built with the same architecture.
+func (model *Sequential) Values() (values []Matrix) {
+	for _, param := range model.Params() {
+		values = append(values, param.Value)
+	}
+
+	return
+}
+
+func (model *Sequential) SetValues(values []Matrix) error {
+	params := model.Params()
+	if len(values) != len(params) {
+		return fmt.Errorf("%d parameters were given, the model has %d", len(values), len(params))
+	}
+
+	for i, param := range params {
+		param.Value = values[i]
+	}
+
+	return nil
+}
+
+// Save writes the values of every parameter. The layers themselves are not
+// saved, so Load expects a model built with the same architecture.
+func (model *Sequential) Save(fileName string) {
+	outF, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o777) // 0666 for windows support TODO()
+	if err != nil {
+		panic("Failed to save the network to " + fileName + ".")
+	}
+	defer outF.Close()
+
+	encoder := json.NewEncoder(outF)
+	err = encoder.Encode(model.Values())
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (model *Sequential) Load(fileName string) error {
+	inF, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer inF.Close()
+
+	var values []Matrix
+	err = json.NewDecoder(inF).Decode(&values)
+	if err != nil {
+		return err
+	}
+
+	return model.SetValues(values)
+}
@@ -0,0 +1,31 @@
+package network
+
+import "testing"
+
+func TestSequentialGradientCheck(t *testing.T) {
+	inputs := RandomMatrix(4, 5)
+	outputs := Matrix{{0, 1, 0}, {1, 0, 0}, {0, 0, 1}, {0, 1, 0}}
+
+	for name, model := range map[string]*Sequential{
+		"dense": NewSequential(0.1, NewDense(5, 3)),
+		"dense sigmoid": NewSequential(0.1,
+			NewDense(5, 6), NewSigmoid(),
+			NewDense(6, 3), NewSigmoid(),
+		),
+		"dense tanh dropout": NewSequential(0.1,
+			NewDense(5, 4), NewTanh(), NewDropout(0.5),
+			NewDense(4, 3), NewSigmoid(),
+		),
+	} {
+		errors := model.GradientCheck(inputs, outputs, 1e-5)
+		if len(errors) != len(model.Params()) {
+			t.Fatalf("%s: %d of the %d parameters were checked", name, len(errors), len(model.Params()))
+		}
+
+		for i, maxError := range errors {
+			if maxError > maxGradientError {
+				t.Errorf("%s: parameter %d has a relative error of %g", name, i, maxError)
+			}
+		}
+	}
+}
@@ -11,9 +11,132 @@ type Network struct {
 	Errors  []float64
 	Time    float64
 	Locale  string
+	// SampleWeights scale the error of every row of Layers, the first
+	// one included.
+	SampleWeights []float64 `json:"-"`
 }
 
 type Derivative struct {
 	Delta      Matrix
 	Adjustment Matrix
 }
+
+type GradientCheck struct {
+	Layer        int
+	WeightsError float64
+	BiasesError  float64
+}
+
+type Parameter struct {
+	Value    Matrix
+	Gradient Matrix
+	Frozen   bool
+}
+
+type Layer interface {
+	Forward(input Matrix, training bool) Matrix
+	Backward(gradient Matrix) Matrix
+	Params() []*Parameter
+}
+
+type Dense struct {
+	Weights *Parameter
+	Biases  *Parameter
+	input   Matrix
+}
+
+type Activation struct {
+	Function   func(x float64) float64
+	Derivative func(y float64) float64
+	output     Matrix
+}
+
+type Dropout struct {
+	Rate float64
+	mask Matrix
+}
+
+type Embedding struct {
+	Vectors *Parameter
+	Pooling string
+	ids     Matrix
+	frozen  map[int]bool
+}
+
+type Sequential struct {
+	Layers        []Layer
+	Rate          float64
+	Errors        []float64
+	SampleWeights []float64
+}
+
+type Variable struct {
+	Value    Matrix
+	Gradient Matrix
+	backward func()
+}
+
+type Tape struct {
+	variables []*Variable
+}
+
+type GRU struct {
+	Embedding     Matrix
+	Wz, Uz, Bz    Matrix
+	Wr, Ur, Br    Matrix
+	Wh, Uh, Bh    Matrix
+	Wy, By        Matrix
+	Rate          float64
+	Errors        []float64
+	Time          float64
+	Locale        string
+	SampleWeights []float64 `json:"-"`
+	random        *Random
+}
+
+type Classifier interface {
+	Train(inputs, outputs Matrix)
+	Predict(input []float64) []float64
+	Save(fileName string) error
+	Load(fileName string) error
+}
+
+type NetworkClassifier struct {
+	Network       Network
+	Locale        string
+	Rate          float64
+	HiddenNodes   int
+	Iterations    int
+	SampleWeights []float64 `json:"-"`
+	Random        *Random   `json:"-"`
+}
+
+type NaiveBayes struct {
+	Smoothing     float64
+	Priors        []float64
+	Likelihoods   Matrix
+	SampleWeights []float64 `json:"-"`
+}
+
+type LogisticRegression struct {
+	Rate           float64
+	Iterations     int
+	Regularization float64
+	Weights        Matrix
+	Biases         Matrix
+	SampleWeights  []float64 `json:"-"`
+}
+
+// KNN keeps the SampleWeights of the vectors it indexes, which weigh their
+// votes.
+type KNN struct {
+	K             int
+	Vectors       Matrix
+	Labels        Matrix
+	SampleWeights []float64 `json:",omitempty"`
+}
+
+type Neighbor struct {
+	Index      int
+	Similarity float64
+}
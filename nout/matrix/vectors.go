@@ -0,0 +1,49 @@
+package network
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadVectors reads word vectors from a text file in the GloVe format, one
+// word per line followed by its values separated by spaces. The words are
+// also returned in the order of the file, where the first vector of a word
+// repeated is kept.
+func LoadVectors(fileName string) (map[string][]float64, []string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	vectors := map[string][]float64{}
+	var words []string
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		if _, exists := vectors[fields[0]]; exists {
+			continue
+		}
+
+		vector := make([]float64, len(fields)-1)
+		for i, field := range fields[1:] {
+			vector[i], err = strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		vectors[fields[0]] = vector
+		words = append(words, fields[0])
+	}
+
+	return vectors, words, scanner.Err()
+}
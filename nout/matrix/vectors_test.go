@@ -0,0 +1,29 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadVectors(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "vectors.txt")
+	err := os.WriteFile(fileName, []byte("world 1 2\nhello 3 4\n\nworld 5 6\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors, words, err := LoadVectors(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(words, []string{"world", "hello"}) {
+		t.Errorf("the words are %v", words)
+	}
+
+	if !reflect.DeepEqual(vectors["world"], []float64{1, 2}) {
+		t.Errorf("the first vector of world was replaced by %v", vectors["world"])
+	}
+}
@@ -20,6 +20,30 @@ func Sigmoid(x float64) float64 {
 	return 1 / (1 + math.Exp(-x))
 }
 
+func SigmoidDerivative(x float64) float64 {
+	return x * (1 - x)
+}
+
+func Tanh(x float64) float64 {
+	return math.Tanh(x)
+}
+
+func TanhDerivative(x float64) float64 {
+	return 1 - x*x
+}
+
+func ReLU(x float64) float64 {
+	return math.Max(0, x)
+}
+
+func ReLUDerivative(x float64) float64 {
+	if x > 0 {
+		return 1
+	}
+
+	return 0
+}
+
 func Index(slice []string, text string) int {
 	for i, item := range slice {
 		if item == text {
@@ -0,0 +1,102 @@
+package training
+
+import (
+	"fmt"
+	"strings"
+
+	matrix "marboris/nout/matrix"
+)
+
+func countTags(documents []Document) (tags []string, counts map[string]int) {
+	counts = map[string]int{}
+	for _, document := range documents {
+		if _, exists := counts[document.Tag]; !exists {
+			tags = append(tags, document.Tag)
+		}
+
+		counts[document.Tag]++
+	}
+
+	return
+}
+
+// BalanceDocuments resamples the documents of every tag at random, repeating
+// them up to the count of the largest tag with Oversampling, or dropping them
+// down to the count of the smallest tag with Undersampling.
+func BalanceDocuments(random *matrix.Random, documents []Document, balancing string) (balanced []Document) {
+	if len(documents) == 0 || balancing != Oversampling && balancing != Undersampling {
+		return documents
+	}
+
+	tags, counts := countTags(documents)
+	byTag := map[string][]Document{}
+	for _, document := range documents {
+		byTag[document.Tag] = append(byTag[document.Tag], document)
+	}
+
+	target := counts[tags[0]]
+	for _, count := range counts {
+		if balancing == Oversampling {
+			target = max(target, count)
+		} else {
+			target = min(target, count)
+		}
+	}
+
+	for _, tag := range tags {
+		tagDocuments := byTag[tag]
+
+		if balancing == Undersampling {
+			for _, i := range random.Perm(len(tagDocuments))[:target] {
+				balanced = append(balanced, tagDocuments[i])
+			}
+
+			continue
+		}
+
+		balanced = append(balanced, tagDocuments...)
+		for i := len(tagDocuments); i < target; i++ {
+			balanced = append(balanced, tagDocuments[random.Intn(len(tagDocuments))])
+		}
+	}
+
+	return balanced
+}
+
+// ClassWeights returns the weight of every document, inversely proportional
+// to the number of documents of its tag so that every tag weighs the same.
+func ClassWeights(documents []Document) (weights []float64) {
+	tags, counts := countTags(documents)
+
+	for _, document := range documents {
+		weights = append(weights, float64(len(documents))/float64(len(tags)*counts[document.Tag]))
+	}
+
+	return weights
+}
+
+func ReportDataset(before, after []Document) (report DatasetReport) {
+	tags, beforeCounts := countTags(before)
+	_, afterCounts := countTags(after)
+
+	for _, tag := range tags {
+		report.Tags = append(report.Tags, TagCount{
+			Tag:    tag,
+			Before: beforeCounts[tag],
+			After:  afterCounts[tag],
+		})
+	}
+
+	return
+}
+
+func (report DatasetReport) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%-30s %8s %8s\n", "Tag", "Before", "After")
+	for _, tag := range report.Tags {
+		fmt.Fprintf(&builder, "%-30s %8d %8d\n", tag.Tag, tag.Before, tag.After)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,215 @@
+package training
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	util "marboris/nout/utils"
+)
+
+// distribution tells whether the raw scores of the model are a probability
+// distribution: the GRU, naive Bayes, logistic regression and kNN output
+// one, the other models independent sigmoids.
+func (model Model) distribution() bool {
+	return model.Type == GRUModel || model.Type == NaiveBayesModel ||
+		model.Type == LogisticModel || model.Type == KNNModel
+}
+
+// logits turns raw scores back into logits.
+func (model Model) logits(scores []float64) []float64 {
+	logits := make([]float64, len(scores))
+	softmax := model.distribution()
+
+	for i, score := range scores {
+		score = math.Min(math.Max(score, 1e-12), 1-1e-12)
+
+		if softmax {
+			logits[i] = math.Log(score)
+		} else {
+			logits[i] = math.Log(score / (1 - score))
+		}
+	}
+
+	return logits
+}
+
+// calibrate divides the logits of the raw scores by the temperature. The
+// distributions are normalized again with a softmax, while the independent
+// sigmoids stay independent so that a threshold keeps its meaning.
+func (model Model) calibrate(scores []float64, temperature float64) []float64 {
+	logits := model.logits(scores)
+	probabilities := make([]float64, len(logits))
+
+	if !model.distribution() {
+		for i, logit := range logits {
+			probabilities[i] = util.Sigmoid(logit / temperature)
+		}
+
+		return probabilities
+	}
+
+	highest := math.Inf(-1)
+	for _, logit := range logits {
+		highest = math.Max(highest, logit/temperature)
+	}
+
+	var sum float64
+	for i, logit := range logits {
+		probabilities[i] = math.Exp(logit/temperature - highest)
+		sum += probabilities[i]
+	}
+
+	for i := range probabilities {
+		probabilities[i] /= sum
+	}
+
+	return probabilities
+}
+
+// likelihood returns the negative log-likelihood of the label given the
+// calibrated probabilities: of the label alone for the distributions, of
+// every class being the label or not for the independent sigmoids.
+func (model Model) likelihood(probabilities []float64, label int) (likelihood float64) {
+	if model.distribution() {
+		return -math.Log(math.Max(probabilities[label], 1e-12))
+	}
+
+	for i, probability := range probabilities {
+		if i == label {
+			likelihood -= math.Log(math.Max(probability, 1e-12))
+		} else {
+			likelihood -= math.Log(math.Max(1-probability, 1e-12))
+		}
+	}
+
+	return
+}
+
+// Calibrate fits the temperature that minimizes the negative log-likelihood
+// of the validation documents. The model is left uncalibrated without any.
+func (model *Model) Calibrate(validation []Document) {
+	if len(validation) == 0 {
+		model.Temperature = 0
+		return
+	}
+
+	var scores [][]float64
+	var labels []int
+	for _, document := range validation {
+		label := -1
+		for i, class := range model.Classes {
+			if class == document.Tag {
+				label = i
+			}
+		}
+
+		if label == -1 {
+			continue
+		}
+
+		scores = append(scores, model.RawScores(document.Sentence.Content))
+		labels = append(labels, label)
+	}
+
+	bestLikelihood := math.Inf(1)
+	for i := 0; i <= 200; i++ {
+		temperature := math.Exp(math.Log(0.05) + float64(i)/200*(math.Log(20)-math.Log(0.05)))
+
+		var likelihood float64
+		for k, score := range scores {
+			likelihood += model.likelihood(model.calibrate(score, temperature), labels[k])
+		}
+
+		if likelihood < bestLikelihood {
+			bestLikelihood = likelihood
+			model.Temperature = temperature
+		}
+	}
+}
+
+// CalibrateAndReport fits the temperature on every other validation document
+// and prints the reliability of the model on the others, which the
+// temperature has not seen. Calibration is opt-in: without validation
+// documents, as with the default ValidationSplit, it says so and leaves the
+// model uncalibrated.
+func (model *Model) CalibrateAndReport(validation []Document) {
+	if len(validation) == 0 {
+		fmt.Println("The model is not calibrated, ValidationSplit keeps no document aside.")
+		return
+	}
+
+	var calibration, held []Document
+	for i, document := range validation {
+		if i%2 == 0 {
+			calibration = append(calibration, document)
+		} else {
+			held = append(held, document)
+		}
+	}
+
+	model.Calibrate(calibration)
+
+	if len(held) == 0 {
+		fmt.Println("The reliability is not reported, a single validation document was kept aside.")
+		return
+	}
+
+	fmt.Print(model.Reliability(held, ReliabilityBuckets))
+}
+
+// Reliability groups the best prediction of every document by confidence and
+// compares the accuracy of each bucket with its average confidence.
+func (model Model) Reliability(documents []Document, buckets int) (report ReliabilityReport) {
+	for i := 0; i < buckets; i++ {
+		report.Buckets = append(report.Buckets, ReliabilityBucket{
+			Lower: float64(i) / float64(buckets),
+			Upper: float64(i+1) / float64(buckets),
+		})
+	}
+
+	for _, document := range documents {
+		best := model.Predict(document.Sentence.Content)[0]
+
+		i := min(int(best.Score*float64(buckets)), buckets-1)
+		report.Buckets[i].Count++
+		report.Buckets[i].Confidence += best.Score
+		if best.Tag == document.Tag {
+			report.Buckets[i].Accuracy++
+		}
+	}
+
+	for i, bucket := range report.Buckets {
+		if bucket.Count == 0 {
+			continue
+		}
+
+		report.Buckets[i].Accuracy /= float64(bucket.Count)
+		report.Buckets[i].Confidence /= float64(bucket.Count)
+
+		report.ExpectedCalibrationError += float64(bucket.Count) / float64(len(documents)) *
+			math.Abs(report.Buckets[i].Accuracy-report.Buckets[i].Confidence)
+	}
+
+	return
+}
+
+func (report ReliabilityReport) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Expected calibration error: %.4f\n", report.ExpectedCalibrationError)
+	fmt.Fprintf(&builder, "%-12s %6s %9s %11s\n", "Confidence", "Count", "Accuracy", "Confidence")
+	for _, bucket := range report.Buckets {
+		if bucket.Count == 0 {
+			fmt.Fprintf(&builder, "%.2f-%.2f    %6d %9s %11s\n", bucket.Lower, bucket.Upper, 0, "-", "-")
+			continue
+		}
+
+		fmt.Fprintf(
+			&builder, "%.2f-%.2f    %6d %9.3f %11.3f\n",
+			bucket.Lower, bucket.Upper, bucket.Count, bucket.Accuracy, bucket.Confidence,
+		)
+	}
+
+	return builder.String()
+}
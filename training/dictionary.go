@@ -0,0 +1,134 @@
+package training
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	util "marboris/nout/utils"
+)
+
+var (
+	dictionariesMutex = sync.Mutex{}
+	dictionaries      = map[string]map[string][]string{}
+)
+
+// GetDictionary returns the replacements of the locale, read the first time
+// from its normalization.json which maps a word to the words it stands for.
+// Without the file, the built-in Normalizations of the locale are used.
+func GetDictionary(locale string) map[string][]string {
+	dictionariesMutex.Lock()
+	defer dictionariesMutex.Unlock()
+
+	if dictionary, exists := dictionaries[locale]; exists {
+		return dictionary
+	}
+
+	entries := Normalizations[locale]
+	bytes, err := os.ReadFile(util.GetResDir("locales", "normalization.json", locale))
+	if err == nil {
+		entries = map[string]string{}
+		err = json.Unmarshal(bytes, &entries)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	dictionary := map[string][]string{}
+	for word, replacement := range entries {
+		dictionary[Normalize(locale, word)] = strings.Fields(Normalize(locale, replacement))
+	}
+
+	dictionaries[locale] = dictionary
+	return dictionary
+}
+
+// ReplaceWords replaces every token found in the dictionary of the locale.
+func ReplaceWords(locale string, tokens []string) (words []string) {
+	dictionary := GetDictionary(locale)
+	for _, token := range tokens {
+		replacement, exists := dictionary[token]
+		if !exists {
+			words = append(words, token)
+			continue
+		}
+
+		words = append(words, replacement...)
+	}
+
+	return
+}
+
+// Without returns a copy of the pipeline without the stage.
+func (pipeline Pipeline) Without(stage string) Pipeline {
+	stages := pipeline.Stages
+	if len(stages) == 0 {
+		stages = DefaultStages
+	}
+
+	pipeline.Stages = nil
+	for _, name := range stages {
+		if name != stage {
+			pipeline.Stages = append(pipeline.Stages, name)
+		}
+	}
+
+	return pipeline
+}
+
+// ReportNormalization compares the vocabulary of the documents analyzed
+// with and without DictionaryStage.
+func ReportNormalization(documents []Document, pipeline Pipeline) (report NormalizationReport) {
+	without := pipeline.Without(DictionaryStage)
+
+	vocabulary := map[string]bool{}
+	for _, document := range documents {
+		for _, word := range pipeline.Run(document.Sentence.Locale, document.Sentence.Content) {
+			vocabulary[word] = true
+		}
+	}
+
+	before := map[string]bool{}
+	for _, document := range documents {
+		for _, word := range without.Run(document.Sentence.Locale, document.Sentence.Content) {
+			before[word] = true
+		}
+	}
+
+	for word := range before {
+		if !vocabulary[word] {
+			report.Collapsed = append(report.Collapsed, word)
+		}
+	}
+	sort.Strings(report.Collapsed)
+
+	for word := range vocabulary {
+		if !before[word] {
+			report.Added = append(report.Added, word)
+		}
+	}
+	sort.Strings(report.Added)
+
+	report.Before = len(before)
+	report.After = len(vocabulary)
+
+	return
+}
+
+func (report NormalizationReport) String() string {
+	message := fmt.Sprintf(
+		"Normalization replaced %d vocabulary entries and added %d, from %d to %d, a reduction of %d.\n",
+		len(report.Collapsed), len(report.Added), report.Before, report.After, report.Before-report.After,
+	)
+	if len(report.Collapsed) > 0 {
+		message += "Replaced: " + strings.Join(report.Collapsed, ", ") + "\n"
+	}
+	if len(report.Added) > 0 {
+		message += "Added: " + strings.Join(report.Added, ", ") + "\n"
+	}
+
+	return message
+}
@@ -0,0 +1,34 @@
+package training
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReportNormalization(t *testing.T) {
+	var documents []Document
+	for _, content := range []string{"whats the weather", "what is the time", "dont go", "thx"} {
+		documents = append(documents, Document{Sentence: Sentence{Locale: "en", Content: content}})
+	}
+
+	pipeline := Pipeline{Stages: []string{NormalizeStage, TokenizeStage, DictionaryStage}}
+	report := ReportNormalization(documents, pipeline)
+
+	if report.Before != 9 || report.After != 9 {
+		t.Errorf("the vocabulary went from %d to %d entries instead of 9 to 9", report.Before, report.After)
+	}
+
+	if collapsed := []string{"dont", "thx", "whats"}; !reflect.DeepEqual(report.Collapsed, collapsed) {
+		t.Errorf("%q were replaced instead of %q", report.Collapsed, collapsed)
+	}
+
+	if added := []string{"do", "not", "thanks"}; !reflect.DeepEqual(report.Added, added) {
+		t.Errorf("%q were added instead of %q", report.Added, added)
+	}
+
+	message := "replaced 3 vocabulary entries and added 3, from 9 to 9, a reduction of 0"
+	if !strings.Contains(report.String(), message) {
+		t.Errorf("the report %q does not say %q", report.String(), message)
+	}
+}
@@ -0,0 +1,132 @@
+package training
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tebeka/snowball"
+	matrix "marboris/nout/matrix"
+)
+
+// PadSequences returns the sequences as rows of the same length, padded with
+// -1 as expected by matrix.Embedding.
+func PadSequences(sequences [][]int) (padded [][]float64) {
+	var length int
+	for _, sequence := range sequences {
+		length = max(length, len(sequence))
+	}
+
+	for _, sequence := range sequences {
+		row := make([]float64, length)
+		for i := range row {
+			row[i] = -1
+			if i < len(sequence) {
+				row[i] = float64(sequence[i])
+			}
+		}
+
+		padded = append(padded, row)
+	}
+
+	return padded
+}
+
+// StemVectors keys the vectors by the stem of their word so that they match
+// the vocabulary from Organize. The first vector of each stem in the order of
+// words, as returned by matrix.LoadVectors, is kept.
+func StemVectors(locale string, words []string, vectors map[string][]float64) map[string][]float64 {
+	stemmer, err := snowball.New(stemmerLanguage(locale))
+	if err != nil {
+		fmt.Println("Stemmer error", err)
+		return vectors
+	}
+
+	stemmed := map[string][]float64{}
+	for _, word := range words {
+		stem := stemmer.Stem(strings.ToLower(word))
+		if _, exists := stemmed[stem]; exists {
+			continue
+		}
+
+		stemmed[stem] = vectors[word]
+	}
+
+	return stemmed
+}
+
+func NewEmbeddingSequential(random *matrix.Random, rate float64, embedding *matrix.Embedding, hiddenNodes, classes int) *matrix.Sequential {
+	dimensions := matrix.Columns(embedding.Vectors.Value)
+
+	return matrix.NewSequential(
+		rate,
+		embedding,
+		matrix.NewDenseFrom(random, dimensions, hiddenNodes),
+		matrix.NewSigmoid(),
+		matrix.NewDenseFrom(random, hiddenNodes, classes),
+		matrix.NewSigmoid(),
+	)
+}
+
+// LoadEmbeddingSequential rebuilds a model created by CreateEmbeddingNetwork
+// from the values of its parameters.
+func LoadEmbeddingSequential(values []matrix.Matrix, pooling string) *matrix.Sequential {
+	embedding := &matrix.Embedding{
+		Vectors: matrix.NewParameter(values[0]),
+		Pooling: pooling,
+	}
+
+	model := NewEmbeddingSequential(nil, 0, embedding, matrix.Columns(values[1]), matrix.Columns(values[3]))
+	model.SetValues(values)
+
+	return model
+}
+
+// CreateEmbedding returns an embedding for words, initialized with the
+// pre-trained vectors of EmbeddingVectors when the locale has some.
+func CreateEmbedding(random *matrix.Random, locale string, words []string) *matrix.Embedding {
+	var vectors map[string][]float64
+	dimensions := EmbeddingDimensions
+	if fileName, exists := EmbeddingVectors[locale]; exists {
+		loaded, loadedWords, err := matrix.LoadVectors(fileName)
+		if err != nil {
+			fmt.Println("Failed to load the vectors of "+fileName+":", err)
+		}
+
+		vectors = StemVectors(locale, loadedWords, loaded)
+		if len(loadedWords) > 0 {
+			dimensions = len(loaded[loadedWords[0]])
+		}
+	}
+
+	embedding := matrix.NewEmbeddingFrom(random, len(words), dimensions)
+	embedding.Pooling = EmbeddingPooling
+	if len(vectors) > 0 {
+		rows := embedding.SetVectors(words, vectors)
+		fmt.Printf("Loaded pre-trained vectors for %d of %d words.\n", len(rows), len(words))
+
+		// The words without a pre-trained vector keep learning theirs.
+		if FreezeEmbeddings && len(rows) > 0 {
+			embedding.Freeze(rows...)
+		}
+	}
+
+	return embedding
+}
+
+func CreateEmbeddingNetwork(locale string, rate float64, hiddenNodes int) (model *matrix.Sequential, err error) {
+	tempDir := os.TempDir()
+	saveFile := filepath.Join(tempDir, "Marboris-Embedding.json")
+
+	bundle, documents := NewModel(locale, EmbeddingModel)
+	err = bundle.Train(documents, rate, hiddenNodes)
+	if err != nil {
+		return nil, err
+	}
+	model = LoadEmbeddingSequential(bundle.Embedding, bundle.Pooling)
+
+	model.Save(saveFile)
+
+	return model, nil
+}
@@ -0,0 +1,22 @@
+package training
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStemVectors(t *testing.T) {
+	vectors := map[string][]float64{
+		"running": {1},
+		"runs":    {2},
+		"walked":  {3},
+	}
+
+	for _, words := range [][]string{{"running", "runs", "walked"}, {"runs", "running", "walked"}} {
+		stemmed := StemVectors("en", words, vectors)
+		expected := map[string][]float64{"run": vectors[words[0]], "walk": {3}}
+		if !reflect.DeepEqual(stemmed, expected) {
+			t.Errorf("%v gave %v instead of %v", words, stemmed, expected)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package training
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	matrix "marboris/nout/matrix"
+)
+
+// EnsembleFile is where CreateEnsemble saves the ensemble.
+func EnsembleFile() string {
+	return filepath.Join(os.TempDir(), "Marboris-Ensemble.json")
+}
+
+// CreateEnsemble trains one model per member on the same documents of the
+// locale, each with its own type, hyperparameters and seed, and saves them
+// as a single ensemble.
+func CreateEnsemble(locale, voting string, members []EnsembleMember) (ensemble Ensemble, err error) {
+	if voting != AverageVoting && voting != MajorityVoting {
+		return Ensemble{}, fmt.Errorf("unknown voting %q", voting)
+	}
+
+	if len(members) == 0 {
+		return Ensemble{}, fmt.Errorf("an ensemble needs at least one member")
+	}
+
+	// The accuracies are compared on a holdout of ComparisonSplit, as
+	// CompareModels does, the rest calibrating with ValidationSplit.
+	base, documents := NewModel(locale, "")
+	documents, held := SplitDocuments(documents, ComparisonSplit)
+	training, validation := SplitDocuments(documents, ValidationSplit)
+
+	ensemble = Ensemble{
+		Locale:    locale,
+		Classes:   base.Classes,
+		Voting:    voting,
+		Members:   members,
+		Threshold: DefaultThreshold,
+	}
+
+	for _, member := range members {
+		model := base
+		model.Type = member.Type
+		if member.Seed != 0 {
+			model.random = matrix.NewRandom(member.Seed)
+		}
+		err = model.Train(training, member.Rate, member.HiddenNodes)
+		if err != nil {
+			return Ensemble{}, err
+		}
+		model.Calibrate(validation)
+
+		ensemble.Models = append(ensemble.Models, model)
+	}
+
+	fmt.Print(ensemble.Evaluate(held))
+
+	ensemble.Save(EnsembleFile())
+
+	return ensemble, nil
+}
+
+// Scores averages the scores of the models, or with MajorityVoting returns the
+// share of the models that chose each class.
+func (ensemble Ensemble) Scores(content string) []float64 {
+	scores := make([]float64, len(ensemble.Classes))
+
+	for _, model := range ensemble.Models {
+		modelScores := model.Scores(content)
+
+		if ensemble.Voting == MajorityVoting {
+			best := 0
+			for i, score := range modelScores {
+				if score > modelScores[best] {
+					best = i
+				}
+			}
+
+			scores[best]++
+			continue
+		}
+
+		for i, score := range modelScores {
+			scores[i] += score
+		}
+	}
+
+	for i := range scores {
+		scores[i] /= float64(len(ensemble.Models))
+	}
+
+	return scores
+}
+
+func (ensemble Ensemble) Predict(content string) []Prediction {
+	return rankPredictions(ensemble.Classes, ensemble.Scores(content))
+}
+
+func (ensemble Ensemble) Classify(content string) Prediction {
+	return ensemble.Threshold.Decide(ensemble.Predict(content))
+}
+
+// Evaluate compares the accuracy of the ensemble on the documents with the
+// accuracy of each of its models.
+func (ensemble Ensemble) Evaluate(documents []Document) (report EnsembleReport) {
+	var correct int
+	for _, document := range documents {
+		if ensemble.Predict(document.Sentence.Content)[0].Tag == document.Tag {
+			correct++
+		}
+	}
+
+	if len(documents) > 0 {
+		report.Accuracy = float64(correct) / float64(len(documents))
+	}
+
+	for _, model := range ensemble.Models {
+		report.MemberAccuracies = append(report.MemberAccuracies, model.Accuracy(documents))
+	}
+
+	return
+}
+
+func (report EnsembleReport) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%-10s %9s\n", "Model", "Accuracy")
+	for i, accuracy := range report.MemberAccuracies {
+		fmt.Fprintf(&builder, "%-10d %9.3f\n", i+1, accuracy)
+	}
+	fmt.Fprintf(&builder, "%-10s %9.3f\n", "Ensemble", report.Accuracy)
+
+	return builder.String()
+}
+
+func (ensemble Ensemble) Save(fileName string) {
+	outF, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o777) // 0666 for windows support TODO()
+	if err != nil {
+		panic("Failed to save the ensemble to " + fileName + ".")
+	}
+	defer outF.Close()
+
+	encoder := json.NewEncoder(outF)
+	err = encoder.Encode(ensemble)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func LoadEnsemble(fileName string) (ensemble Ensemble, err error) {
+	inF, err := os.Open(fileName)
+	if err != nil {
+		return Ensemble{}, err
+	}
+	defer inF.Close()
+
+	err = json.NewDecoder(inF).Decode(&ensemble)
+	if err != nil {
+		return Ensemble{}, err
+	}
+
+	if len(ensemble.Models) == 0 {
+		return Ensemble{}, fmt.Errorf("the ensemble of %s has no models", fileName)
+	}
+
+	for i := range ensemble.Models {
+		err = ensemble.Models[i].Validate()
+		if err != nil {
+			return Ensemble{}, err
+		}
+
+		ensemble.Models[i].Pipeline.Spelling.Index(ensemble.Models[i].Pipeline.Spelling.Words)
+	}
+
+	return ensemble, nil
+}
@@ -0,0 +1,50 @@
+package training
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explain reports how much each word of the content contributes to the best
+// tag, as the drop of its score when the word is left out.
+func (model Model) Explain(content string) (explanation Explanation) {
+	sentence := model.sentence(content)
+	sentence.arrange()
+
+	best := model.Predict(sentence.Content)[0]
+	explanation.Tag = best.Tag
+	explanation.Score = best.Score
+
+	index := 0
+	for i, class := range model.Classes {
+		if class == best.Tag {
+			index = i
+		}
+	}
+
+	words := Tokenize(model.Locale, sentence.Content)
+	for i, word := range words {
+		others := append(append([]string{}, words[:i]...), words[i+1:]...)
+		score := model.Scores(strings.Join(others, " "))[index]
+
+		explanation.Tokens = append(explanation.Tokens, TokenContribution{
+			Token:        word,
+			Stem:         strings.Join(model.sentence(word).stem(), " "),
+			Contribution: best.Score - score,
+		})
+	}
+
+	return
+}
+
+func (explanation Explanation) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Tag: %s (%.4f)\n", explanation.Tag, explanation.Score)
+	fmt.Fprintf(&builder, "%-20s %-20s %12s\n", "Token", "Stem", "Contribution")
+	for _, token := range explanation.Tokens {
+		fmt.Fprintf(&builder, "%-20s %-20s %+12.4f\n", token.Token, token.Stem, token.Contribution)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,239 @@
+package training
+
+import (
+	"fmt"
+
+	matrix "marboris/nout/matrix"
+	util "marboris/nout/utils"
+)
+
+// FineTuneModel loads the model bundle saved in fileName and adapts it to the
+// current intents of its locale: the inputs grow for new stems, the outputs
+// for new classes, and the learned weights are kept before training briefly
+// on every document. The patterns are analyzed with the pipeline of the
+// saved model. The accuracy on the documents of the previous classes is
+// compared before and after to catch forgetting. With multiLabel, the
+// outputs are trained to be independent, whatever the saved model was.
+func FineTuneModel(fileName string, rate float64, multiLabel bool) (model Model, report FineTuneReport, err error) {
+	previous, err := LoadModel(fileName)
+	if err != nil {
+		return Model{}, report, err
+	}
+
+	model, documents := NewModelWith(previous.Locale, previous.Type, previous.Pipeline)
+	model.Threshold = previous.Threshold
+	model.MultiLabel = multiLabel
+	model.Vectorizer = Vectorizer{
+		Type:       previous.Vectorizer.Type,
+		Dimensions: previous.Vectorizer.Dimensions,
+	}
+
+	report.NewWords = newValues(previous.Words, model.Words)
+	report.NewClasses = newValues(previous.Classes, model.Classes)
+
+	var previousDocuments []Document
+	for _, document := range documents {
+		if util.Contains(previous.Classes, document.Tag) {
+			previousDocuments = append(previousDocuments, document)
+		}
+	}
+	report.OldAccuracyBefore = previous.Accuracy(previousDocuments)
+
+	training, validation := SplitDocuments(documents, ValidationSplit)
+
+	err = model.FineTune(previous, training, rate, FineTuneIterations)
+	if err != nil {
+		return Model{}, report, err
+	}
+	if model.MultiLabel {
+		validation = append(validation, CombineDocuments(nil, model.Locale, validation, len(validation))...)
+		model.FitThresholds(validation)
+	} else {
+		model.Calibrate(validation)
+	}
+
+	model.ReportOutOfScope()
+
+	report.OldAccuracyAfter = model.Accuracy(previousDocuments)
+	report.Forgetting = report.OldAccuracyBefore-report.OldAccuracyAfter > ForgettingTolerance
+
+	fmt.Printf(
+		"Fine-tuned with %d new stems and %d new classes, the accuracy on the previous classes went from %.3f to %.3f.\n",
+		len(report.NewWords), len(report.NewClasses), report.OldAccuracyBefore, report.OldAccuracyAfter,
+	)
+	if report.Forgetting {
+		fmt.Println("Warning: the model forgot part of the previous classes.")
+	}
+
+	model.Save(fileName)
+
+	return model, report, nil
+}
+
+// FineTune builds the weights of the model for its words and classes from
+// those of previous, which must be of the same type, and trains them on the
+// documents.
+func (model *Model) FineTune(previous Model, documents []Document, rate float64, iterations int) error {
+	if previous.Type != model.Type {
+		return fmt.Errorf("cannot fine-tune a %q model as %q", previous.Type, model.Type)
+	}
+
+	rows := indexes(previous.Words, model.Words)
+	columns := indexes(previous.Classes, model.Classes)
+
+	if model.MultiLabel {
+		if model.Type != NetworkModel && model.Type != EmbeddingModel {
+			return fmt.Errorf("the %q model type has no independent outputs for multi-label mode", model.Type)
+		}
+
+		documents = append(documents, CombineDocuments(model.random, model.Locale, documents, MultiLabelCombinations)...)
+	}
+
+	switch model.Type {
+	case NetworkModel:
+		var hiddensNodes []int
+		for _, weights := range previous.Network.Weights[:len(previous.Network.Weights)-1] {
+			hiddensNodes = append(hiddensNodes, matrix.Columns(weights))
+		}
+
+		inputs, outputs := model.vectorize(documents)
+		network := matrix.CreateNetwork(model.Locale, rate, inputs, outputs, hiddensNodes...)
+
+		last := len(network.Weights) - 1
+		for i := range network.Weights {
+			var weightsRows, weightsColumns []int
+			// Hashed inputs keep their indexes whatever the words.
+			if i == 0 && model.Vectorizer.Type != HashingVectorizer {
+				weightsRows = rows
+			}
+			if i == last {
+				weightsColumns = columns
+			}
+
+			transfer(previous.Network.Weights[i], network.Weights[i], weightsRows, weightsColumns)
+			// The biases have a row per training document, which are not the
+			// same documents, so every row starts from their average.
+			transfer(
+				meanRows(previous.Network.Biases[i]), network.Biases[i],
+				make([]int, matrix.Rows(network.Biases[i])), weightsColumns,
+			)
+		}
+
+		network.Train(iterations)
+		model.Network = &network
+	case GRUModel:
+		gru := matrix.CreateGRU(
+			model.Locale, rate, len(model.Words),
+			matrix.Columns(previous.GRU.Embedding), matrix.Columns(previous.GRU.Uz), len(model.Classes),
+		)
+
+		transfer(previous.GRU.Embedding, gru.Embedding, rows, nil)
+		for _, weights := range [][2]matrix.Matrix{
+			{previous.GRU.Wz, gru.Wz}, {previous.GRU.Uz, gru.Uz}, {previous.GRU.Bz, gru.Bz},
+			{previous.GRU.Wr, gru.Wr}, {previous.GRU.Ur, gru.Ur}, {previous.GRU.Br, gru.Br},
+			{previous.GRU.Wh, gru.Wh}, {previous.GRU.Uh, gru.Uh}, {previous.GRU.Bh, gru.Bh},
+		} {
+			transfer(weights[0], weights[1], nil, nil)
+		}
+		transfer(previous.GRU.Wy, gru.Wy, nil, columns)
+		transfer(previous.GRU.By, gru.By, nil, columns)
+
+		sequences, outputs := Sequences(model.Words, model.Classes, documents)
+		gru.Train(sequences, outputs, iterations)
+		model.GRU = &gru
+	case EmbeddingModel:
+		embedding := CreateEmbedding(model.random, model.Locale, model.Words)
+		embedding.Pooling = previous.Pooling
+		sequential := NewEmbeddingSequential(model.random, rate, embedding, matrix.Columns(previous.Embedding[1]), len(model.Classes))
+
+		values := sequential.Values()
+		transfer(previous.Embedding[0], values[0], rows, nil)
+		transfer(previous.Embedding[1], values[1], nil, nil)
+		transfer(previous.Embedding[2], values[2], nil, nil)
+		transfer(previous.Embedding[3], values[3], nil, columns)
+		transfer(previous.Embedding[4], values[4], nil, columns)
+
+		sequences, outputs := Sequences(model.Words, model.Classes, documents)
+		sequential.Train(PadSequences(sequences), outputs, iterations)
+		model.Embedding = sequential.Values()
+		model.Pooling = embedding.Pooling
+	case NaiveBayesModel, LogisticModel, KNNModel:
+		// These baselines are cheap enough to train again from scratch.
+		return model.Train(documents, rate, 0)
+	default:
+		return fmt.Errorf("unknown model type %q", model.Type)
+	}
+
+	return nil
+}
+
+// indexes returns the index in previous of every value of current, or -1.
+func indexes(previous, current []string) []int {
+	positions := map[string]int{}
+	for i, value := range previous {
+		positions[value] = i
+	}
+
+	result := make([]int, len(current))
+	for i, value := range current {
+		position, exists := positions[value]
+		if !exists {
+			position = -1
+		}
+
+		result[i] = position
+	}
+
+	return result
+}
+
+func newValues(previous, current []string) (values []string) {
+	for i, position := range indexes(previous, current) {
+		if position == -1 {
+			values = append(values, current[i])
+		}
+	}
+
+	return
+}
+
+// meanRows returns the average of the rows of values as a single row.
+func meanRows(values matrix.Matrix) matrix.Matrix {
+	mean := matrix.CreateMatrix(1, matrix.Columns(values))
+	for _, row := range values {
+		for j, value := range row {
+			mean[0][j] += value / float64(len(values))
+		}
+	}
+
+	return mean
+}
+
+// transfer copies previous into current, where row i and column j of current
+// come from row rows[i] and column columns[j] of previous. A nil mapping keeps
+// the same indexes, and values with no match in previous are left as they are.
+func transfer(previous, current matrix.Matrix, rows, columns []int) {
+	for i := range current {
+		row := i
+		if rows != nil {
+			row = rows[i]
+		}
+
+		if row < 0 || row >= len(previous) {
+			continue
+		}
+
+		for j := range current[i] {
+			column := j
+			if columns != nil {
+				column = columns[j]
+			}
+
+			if column < 0 || column >= len(previous[row]) {
+				continue
+			}
+
+			current[i][j] = previous[row][column]
+		}
+	}
+}
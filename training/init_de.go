@@ -0,0 +1,265 @@
+package training
+
+import util "marboris/nout/utils"
+
+// GermanDatives gives the names of the countries whose adjectives or plural
+// are inflected after "von", from their name in the nominative.
+var GermanDatives = map[string]string{
+	"Vereinigte Staaten":           "von den Vereinigten Staaten",
+	"Vereinigte Arabische Emirate": "von den Vereinigten Arabischen Emiraten",
+	"Niederlande":                  "von den Niederlanden",
+	"Vereinigtes Königreich":       "vom Vereinigten Königreich",
+	"Dominikanische Republik":      "von der Dominikanischen Republik",
+	"Tschechische Republik":        "von der Tschechischen Republik",
+	"Zentralafrikanische Republik": "von der Zentralafrikanischen Republik",
+}
+
+// ArticleCountriesGerman returns the name of the country after "von", with
+// its article in the dative: "von Frankreich", "von der Schweiz", "vom Iran",
+// "von den Niederlanden". The name is in the nominative, as in the dataset.
+func ArticleCountriesGerman(name string) string {
+	if dative, exists := GermanDatives[name]; exists {
+		return dative
+	}
+
+	switch {
+	case util.Contains([]string{"Schweiz", "Türkei", "Ukraine", "Slowakei", "Mongolei", "Elfenbeinküste"}, name):
+		return "von der " + name
+	case util.Contains([]string{"Philippinen", "Malediven", "Seychellen", "Komoren", "Bahamas"}, name):
+		return "von den " + name
+	case util.Contains([]string{"Iran", "Irak", "Libanon", "Sudan", "Jemen", "Kongo", "Tschad", "Oman", "Senegal", "Vatikan"}, name):
+		return "vom " + name
+	}
+
+	return "von " + name
+}
+
+func init() {
+	Locales = append(Locales, Locale{
+		Tag:  "de",
+		Name: "german",
+	})
+
+	ArticleCountriesm["de"] = ArticleCountriesGerman
+
+	Conjunctions["de"] = "und"
+	SpellingDistances["de"] = 2
+	ClarificationOrdinals["de"] = [][]string{
+		{"erste", "ersten", "erstes", "1"},
+		{"zweite", "zweiten", "zweites", "2"},
+	}
+
+	MoviesGenres["de"] = []string{
+		"Action", "Abenteuer", "Animation", "Kinder", "Komödie", "Krimi", "Dokumentarfilm", "Drama", "Fantasy",
+		"Film noir", "Horror", "Musical", "Mystery", "Liebesfilm", "Science-Fiction", "Thriller", "Krieg",
+		"Western",
+	}
+	MathDecimals["de"] = `(?i)(\d+( |-)(nachkomma|dezimal)stelle(n)?)|((nachkomma|dezimal)stelle(n)? \d+)`
+
+	DefaultStopWords["de"] = []string{
+		"der", "die", "das", "den", "dem", "des", "ein", "eine", "einen", "einem", "einer", "und", "oder",
+		"in", "im", "ist", "zu", "zum", "zur", "von", "vom", "mit", "für", "auf", "nicht", "es",
+	}
+
+	DefaultMessages["de"] = []Message{
+		{Tag: OutOfScopeTag, Messages: []string{"Entschuldigung, das verstehe ich nicht"}},
+		{Tag: ClarificationTag, Messages: []string{"Meintest du %s oder %s?"}},
+		{Tag: "no country", Messages: []string{"Ich habe kein Land gefunden"}},
+		{Tag: "no genres", Messages: []string{"Ich habe kein Genre gefunden"}},
+		{Tag: "no genres saved", Messages: []string{"Du hast mir deine Lieblingsgenres noch nicht genannt"}},
+		{Tag: "don't know name", Messages: []string{"Ich kenne deinen Namen noch nicht"}},
+		{Tag: "no name", Messages: []string{"Ich habe keinen Namen gefunden"}},
+		{Tag: "math not valid", Messages: []string{"Diese Rechnung ist nicht gültig"}},
+		{Tag: "no random range", Messages: []string{"Ich brauche zwei Zahlen für den Bereich"}},
+		{Tag: "no jokes", Messages: []string{"Ich habe keinen Witz gefunden"}},
+		{Tag: "no advices", Messages: []string{"Ich habe keinen Rat gefunden"}},
+	}
+
+	DefaultIntents["de"] = []Intent{
+		{
+			Tag:       "hello",
+			Patterns:  []string{"Hallo", "Guten Tag", "Guten Morgen", "Servus", "Hallo mein Freund"},
+			Responses: []string{"Hallo!", "Hi!"},
+		},
+		{
+			Tag:       "goodbye",
+			Patterns:  []string{"Tschüss", "Auf Wiedersehen", "Bis später", "Ich muss gehen", "Bis bald"},
+			Responses: []string{"Tschüss!"},
+		},
+		{
+			Tag:       "thanks",
+			Patterns:  []string{"Danke", "Vielen Dank", "Danke schön", "Ich danke dir"},
+			Responses: []string{"Gern geschehen"},
+		},
+	}
+
+	RegisterModules("de", []Modulem{
+		{
+			Tag: AreaTag,
+			Patterns: []string{
+				"Wie groß ist die Fläche von ",
+				"Gib mir die Fläche von ",
+			},
+			Responses: []string{
+				"Die Fläche %s beträgt %gkm²",
+			},
+			Replacer: AreaReplacer,
+		},
+
+		{
+			Tag: CapitalTag,
+			Patterns: []string{
+				"Was ist die Hauptstadt von ",
+				"Wie heißt die Hauptstadt von ",
+				"Gib mir die Hauptstadt von ",
+			},
+			Responses: []string{
+				"Die Hauptstadt %s ist %s",
+			},
+			Replacer: CapitalReplacer,
+		},
+
+		{
+			Tag: CurrencyTag,
+			Patterns: []string{
+				"Welche Währung wird verwendet in ",
+				"Gib mir die Währung von ",
+				"Was ist die Währung von ",
+			},
+			Responses: []string{
+				"Die Währung %s ist %s",
+			},
+			Replacer: CurrencyReplacer,
+		},
+
+		{
+			Tag: MathTag,
+			Patterns: []string{
+				"Gib mir das Ergebnis von ",
+				"Berechne ",
+			},
+			Responses: []string{
+				"Das Ergebnis ist %s",
+				"Das ergibt %s",
+			},
+			Replacer: MathReplacer,
+		},
+
+		{
+			Tag: GenresTag,
+			Patterns: []string{
+				"Meine Lieblingsgenres sind Komödie und Horror",
+				"Ich mag die Genres Komödie und Horror",
+				"Ich mag Kriegsfilme",
+				"Ich mag Actionfilme",
+			},
+			Responses: []string{
+				"Gute Wahl! Ich habe diese Filmgenres in deinem Client gespeichert.",
+				"Verstanden, ich habe diese Filmgenres in deinem Client gespeichert.",
+			},
+			Replacer: GenresReplacer,
+		},
+
+		{
+			Tag: MoviesTag,
+			Patterns: []string{
+				"Finde mir einen Film über",
+				"Gib mir einen Film mit",
+				"Finde mir einen Film",
+			},
+			Responses: []string{
+				"Ich habe den Film „%s“ für dich gefunden, er ist mit %.02f/5 bewertet",
+				"Klar, hier ist der Film „%s“, er ist mit %.02f/5 bewertet",
+			},
+			Replacer: MovieSearchReplacer,
+		},
+
+		{
+			Tag: MoviesAlreadyTag,
+			Patterns: []string{
+				"Den Film habe ich schon gesehen",
+				"Ich habe diesen Film schon gesehen",
+				"Oh, den kenne ich schon",
+			},
+			Responses: []string{
+				"Ach so, hier ist ein anderer: „%s“, bewertet mit %.02f/5",
+			},
+			Replacer: MovieSearchReplacer,
+		},
+
+		{
+			Tag: MoviesDataTag,
+			Patterns: []string{
+				"Mir ist langweilig",
+				"Ich weiß nicht, was ich tun soll",
+			},
+			Responses: []string{
+				"Ich schlage dir den %s-Film „%s“ vor, bewertet mit %.02f/5",
+			},
+			Replacer: MovieSearchFromInformationReplacer,
+		},
+
+		{
+			Tag: NameGetterTag,
+			Patterns: []string{
+				"Kennst du meinen Namen?",
+				"Wie heiße ich?",
+			},
+			Responses: []string{
+				"Du heißt %s!",
+			},
+			Replacer: NameGetterReplacer,
+		},
+
+		{
+			Tag: NameSetterTag,
+			Patterns: []string{
+				"Ich heiße ",
+				"Mein Name ist ",
+				"Du kannst mich nennen ",
+			},
+			Responses: []string{
+				"Super! Hallo %s",
+			},
+			Replacer: NameSetterReplacer,
+		},
+
+		{
+			Tag: RandomTag,
+			Patterns: []string{
+				"Gib mir eine Zufallszahl",
+				"Erzeuge eine zufällige Zahl",
+			},
+			Responses: []string{
+				"Die Zahl ist %s",
+			},
+			Replacer: RandomNumberReplacer,
+		},
+
+		{
+			Tag: JokesTag,
+			Patterns: []string{
+				"Erzähl mir einen Witz",
+				"Bring mich zum Lachen",
+			},
+			Responses: []string{
+				"Hier, %s",
+				"Hier ist einer, %s",
+			},
+			Replacer: JokesReplacer,
+		},
+		{
+			Tag: AdvicesTag,
+			Patterns: []string{
+				"Gib mir einen Rat",
+				"Berate mich",
+			},
+			Responses: []string{
+				"Hier, %s",
+				"Hier ist einer, %s",
+				"Hör gut zu, %s",
+			},
+			Replacer: AdvicesReplacer,
+		},
+	})
+}
@@ -0,0 +1,21 @@
+package training
+
+import "testing"
+
+func TestArticleCountriesGerman(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Frankreich":                   "von Frankreich",
+		"Schweiz":                      "von der Schweiz",
+		"Iran":                         "vom Iran",
+		"Philippinen":                  "von den Philippinen",
+		"Vereinigte Staaten":           "von den Vereinigten Staaten",
+		"Niederlande":                  "von den Niederlanden",
+		"Vereinigtes Königreich":       "vom Vereinigten Königreich",
+		"Dominikanische Republik":      "von der Dominikanischen Republik",
+		"Vereinigte Arabische Emirate": "von den Vereinigten Arabischen Emiraten",
+	} {
+		if article := ArticleCountriesGerman(name); article != expected {
+			t.Errorf("%q gave %q instead of %q", name, article, expected)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package training
+
+import util "marboris/nout/utils"
+
+// ArticleCountriesSpanish returns the name of the country after "de", with
+// its article: "de España", "del Reino Unido", "de los Países Bajos".
+func ArticleCountriesSpanish(name string) string {
+	switch {
+	case util.Contains([]string{"Reino Unido", "Líbano", "Perú", "Salvador", "Congo"}, name):
+		return "del " + name
+	case util.Contains([]string{"Países Bajos", "Emiratos Árabes Unidos"}, name):
+		return "de los " + name
+	case util.Contains([]string{"India", "República Checa", "República Dominicana"}, name):
+		return "de la " + name
+	}
+
+	return "de " + name
+}
+
+func init() {
+	Locales = append(Locales, Locale{
+		Tag:  "es",
+		Name: "spanish",
+	})
+
+	ArticleCountriesm["es"] = ArticleCountriesSpanish
+
+	Conjunctions["es"] = "y"
+	SpellingDistances["es"] = 2
+	ClarificationOrdinals["es"] = [][]string{
+		{"primero", "primera", "1"},
+		{"segundo", "segunda", "2"},
+	}
+
+	MoviesGenres["es"] = []string{
+		"Acción", "Aventura", "Animación", "Infantil", "Comedia", "Crimen", "Documental", "Drama", "Fantasía",
+		"Cine negro", "Terror", "Musical", "Misterio", "Romance", "Ciencia ficción", "Suspense", "Guerra",
+		"Western",
+	}
+	MathDecimals["es"] = `(\d+( |-)decimal(es)?)|(número (de )?decimal(es)? (es )?\d+)`
+
+	DefaultStopWords["es"] = []string{
+		"el", "la", "los", "las", "un", "una", "unos", "unas", "de", "del", "al", "a", "y", "o",
+		"en", "es", "por", "para", "con", "que", "se", "lo", "su", "sus", "no",
+	}
+
+	DefaultMessages["es"] = []Message{
+		{Tag: OutOfScopeTag, Messages: []string{"Lo siento, no entiendo"}},
+		{Tag: ClarificationTag, Messages: []string{"¿Querías decir %s o %s?"}},
+		{Tag: "no country", Messages: []string{"No encontré ningún país"}},
+		{Tag: "no genres", Messages: []string{"No encontré ningún género"}},
+		{Tag: "no genres saved", Messages: []string{"Aún no me has dicho tus géneros favoritos"}},
+		{Tag: "don't know name", Messages: []string{"Todavía no sé tu nombre"}},
+		{Tag: "no name", Messages: []string{"No encontré ningún nombre"}},
+		{Tag: "math not valid", Messages: []string{"Esta operación no es válida"}},
+		{Tag: "no random range", Messages: []string{"Necesito dos números para el intervalo"}},
+		{Tag: "no jokes", Messages: []string{"No encontré ningún chiste"}},
+		{Tag: "no advices", Messages: []string{"No encontré ningún consejo"}},
+	}
+
+	DefaultIntents["es"] = []Intent{
+		{
+			Tag:       "hello",
+			Patterns:  []string{"Hola", "Buenos días", "Buenas tardes", "Buenas noches", "Hola amigo"},
+			Responses: []string{"¡Hola!", "¡Buenas!"},
+		},
+		{
+			Tag:       "goodbye",
+			Patterns:  []string{"Adiós", "Hasta luego", "Nos vemos", "Me tengo que ir", "Hasta pronto"},
+			Responses: []string{"¡Adiós!"},
+		},
+		{
+			Tag:       "thanks",
+			Patterns:  []string{"Gracias", "Muchas gracias", "Te lo agradezco", "Mil gracias"},
+			Responses: []string{"De nada"},
+		},
+	}
+
+	RegisterModules("es", []Modulem{
+		{
+			Tag: AreaTag,
+			Patterns: []string{
+				"¿Cuál es la superficie de ",
+				"Dame la superficie de ",
+			},
+			Responses: []string{
+				"La superficie %s es de %gkm²",
+			},
+			Replacer: AreaReplacer,
+		},
+
+		{
+			Tag: CapitalTag,
+			Patterns: []string{
+				"¿Cuál es la capital de ",
+				"Dime la capital de ",
+				"Dame la capital de ",
+			},
+			Responses: []string{
+				"La capital %s es %s",
+			},
+			Replacer: CapitalReplacer,
+		},
+
+		{
+			Tag: CurrencyTag,
+			Patterns: []string{
+				"¿Qué moneda se usa en ",
+				"Dame la moneda de ",
+				"¿Cuál es la moneda de ",
+			},
+			Responses: []string{
+				"La moneda %s es %s",
+			},
+			Replacer: CurrencyReplacer,
+		},
+
+		{
+			Tag: MathTag,
+			Patterns: []string{
+				"Dame el resultado de ",
+				"Calcula ",
+			},
+			Responses: []string{
+				"El resultado es %s",
+				"Da %s",
+			},
+			Replacer: MathReplacer,
+		},
+
+		{
+			Tag: GenresTag,
+			Patterns: []string{
+				"Mis géneros de películas favoritos son comedia y terror",
+				"Me gustan los géneros comedia y terror",
+				"Me gustan las películas de guerra",
+				"Me gustan las películas de acción",
+			},
+			Responses: []string{
+				"¡Buena elección! Guardé estos géneros de películas en tu cliente.",
+				"Entendido, guardé estos géneros de películas en tu cliente.",
+			},
+			Replacer: GenresReplacer,
+		},
+
+		{
+			Tag: MoviesTag,
+			Patterns: []string{
+				"Búscame una película de",
+				"Dame una película sobre",
+				"Búscame una película",
+			},
+			Responses: []string{
+				"Encontré la película «%s» para ti, que tiene una nota de %.02f/5",
+				"Claro, aquí tienes la película «%s», que tiene una nota de %.02f/5",
+			},
+			Replacer: MovieSearchReplacer,
+		},
+
+		{
+			Tag: MoviesAlreadyTag,
+			Patterns: []string{
+				"Ya vi esa película",
+				"Ya he visto esa película",
+				"Oh, ya vi esa",
+			},
+			Responses: []string{
+				"Ah, entiendo, aquí tienes otra: «%s», con una nota de %.02f/5",
+			},
+			Replacer: MovieSearchReplacer,
+		},
+
+		{
+			Tag: MoviesDataTag,
+			Patterns: []string{
+				"Estoy aburrido",
+				"No sé qué hacer",
+			},
+			Responses: []string{
+				"Te propongo ver la película de %s «%s», con una nota de %.02f/5",
+			},
+			Replacer: MovieSearchFromInformationReplacer,
+		},
+
+		{
+			Tag: NameGetterTag,
+			Patterns: []string{
+				"¿Sabes mi nombre?",
+				"¿Cómo me llamo?",
+			},
+			Responses: []string{
+				"¡Te llamas %s!",
+			},
+			Replacer: NameGetterReplacer,
+		},
+
+		{
+			Tag: NameSetterTag,
+			Patterns: []string{
+				"Me llamo ",
+				"Mi nombre es ",
+				"Puedes llamarme ",
+			},
+			Responses: []string{
+				"¡Genial! Hola %s",
+			},
+			Replacer: NameSetterReplacer,
+		},
+
+		{
+			Tag: RandomTag,
+			Patterns: []string{
+				"Dame un número aleatorio",
+				"Genera un número al azar",
+			},
+			Responses: []string{
+				"El número es %s",
+			},
+			Replacer: RandomNumberReplacer,
+		},
+
+		{
+			Tag: JokesTag,
+			Patterns: []string{
+				"Cuéntame un chiste",
+				"Hazme reír",
+			},
+			Responses: []string{
+				"Aquí tienes, %s",
+				"Aquí va uno, %s",
+			},
+			Replacer: JokesReplacer,
+		},
+		{
+			Tag: AdvicesTag,
+			Patterns: []string{
+				"Dame un consejo",
+				"Aconséjame",
+			},
+			Responses: []string{
+				"Aquí tienes, %s",
+				"Aquí va uno, %s",
+				"Escucha bien, %s",
+			},
+			Replacer: AdvicesReplacer,
+		},
+	})
+}
@@ -0,0 +1,245 @@
+package training
+
+func init() {
+	Locales = append(Locales, Locale{
+		Tag:  "fa",
+		Name: "persian",
+	})
+
+	Normalizers["fa"] = NormalizePersian
+	Stemmers["fa"] = StemPersian
+	ArticleCountriesm["fa"] = func(name string) string {
+		return name
+	}
+
+	Conjunctions["fa"] = "و"
+	SpellingDistances["fa"] = 1
+	NameSlots["fa"] = []string{"اسم من", "اسمم", "نام من", "نامم"}
+	ClarificationOrdinals["fa"] = [][]string{
+		{"اول", "اولی", "1"},
+		{"دوم", "دومی", "2"},
+	}
+
+	MoviesGenres["fa"] = []string{
+		"اکشن", "ماجراجویی", "انیمیشن", "کودکان", "کمدی", "جنایی", "مستند", "درام", "فانتزی",
+		"نوآر", "ترسناک", "موزیکال", "معمایی", "عاشقانه", "علمی تخیلی", "هیجان انگیز", "جنگی", "وسترن",
+	}
+	MathDecimals["fa"] = `(\d+ (رقم|عدد) (اعشار|بعد از ممیز))|(اعشار \d+)`
+
+	CountryNames["fa"] = map[string]string{
+		"AE": "امارات", "AF": "افغانستان", "AM": "ارمنستان", "AR": "آرژانتین", "AT": "اتریش",
+		"AU": "استرالیا", "AZ": "آذربایجان", "BE": "بلژیک", "BR": "برزیل", "CA": "کانادا",
+		"CH": "سوئیس", "CN": "چین", "DE": "آلمان", "EG": "مصر", "ES": "اسپانیا",
+		"FR": "فرانسه", "GB": "انگلستان", "GR": "یونان", "IN": "هند", "IQ": "عراق",
+		"IR": "ایران", "IT": "ایتالیا", "JO": "اردن", "JP": "ژاپن", "KR": "کره جنوبی",
+		"KW": "کویت", "LB": "لبنان", "MX": "مکزیک", "NL": "هلند", "NO": "نروژ",
+		"PK": "پاکستان", "PT": "پرتغال", "QA": "قطر", "RU": "روسیه", "SA": "عربستان",
+		"SE": "سوئد", "SY": "سوریه", "TJ": "تاجیکستان", "TR": "ترکیه", "US": "آمریکا",
+	}
+
+	DefaultStopWords["fa"] = []string{
+		"و", "در", "به", "از", "که", "این", "آن", "را", "رو", "با", "برای", "تا", "هم", "بر",
+		"یا", "هر", "اما", "نیز", "خود", "است", "هست", "بود", "شد", "یک", "ای", "پس", "اگر",
+	}
+
+	DefaultMessages["fa"] = []Message{
+		{Tag: OutOfScopeTag, Messages: []string{"متاسفم، متوجه نشدم", "ببخشید، منظورت را نفهمیدم"}},
+		{Tag: ClarificationTag, Messages: []string{"منظورت %s بود یا %s؟"}},
+		{Tag: "no country", Messages: []string{"کشوری پیدا نکردم"}},
+		{Tag: "no genres", Messages: []string{"ژانری پیدا نکردم"}},
+		{Tag: "no genres saved", Messages: []string{"هنوز ژانر مورد علاقه‌ات را به من نگفته‌ای"}},
+		{Tag: "don't know name", Messages: []string{"هنوز اسمت را نمی‌دانم"}},
+		{Tag: "no name", Messages: []string{"اسمی پیدا نکردم"}},
+		{Tag: "math not valid", Messages: []string{"این محاسبه معتبر نیست"}},
+		{Tag: "no random range", Messages: []string{"دو عدد برای بازه لازم است"}},
+		{Tag: "no jokes", Messages: []string{"الان جوکی پیدا نکردم"}},
+		{Tag: "no advices", Messages: []string{"الان توصیه‌ای پیدا نکردم"}},
+	}
+
+	DefaultIntents["fa"] = []Intent{
+		{
+			Tag:       "hello",
+			Patterns:  []string{"سلام", "درود", "صبح بخیر", "عصر بخیر", "سلام دوست من"},
+			Responses: []string{"سلام!", "درود!"},
+		},
+		{
+			Tag:       "goodbye",
+			Patterns:  []string{"خداحافظ", "فعلا", "بعدا می‌بینمت", "باید بروم"},
+			Responses: []string{"خداحافظ!"},
+		},
+		{
+			Tag:       "thanks",
+			Patterns:  []string{"ممنون", "مرسی", "متشکرم", "خیلی ممنون", "سپاس"},
+			Responses: []string{"خواهش می‌کنم"},
+		},
+	}
+
+	RegisterModules("fa", []Modulem{
+		{
+			Tag: AreaTag,
+			Patterns: []string{
+				"مساحت چقدر است",
+				"مساحت کشور را بگو",
+			},
+			Responses: []string{
+				"مساحت %s برابر %g کیلومتر مربع است",
+			},
+			Replacer: AreaReplacer,
+		},
+
+		{
+			Tag: CapitalTag,
+			Patterns: []string{
+				"پایتخت کجاست",
+				"پایتخت چیست",
+				"پایتخت کشور را بگو",
+			},
+			Responses: []string{
+				"پایتخت %s، %s است",
+			},
+			Replacer: CapitalReplacer,
+		},
+
+		{
+			Tag: CurrencyTag,
+			Patterns: []string{
+				"واحد پول چیست",
+				"پول رایج چیست",
+				"واحد پول کشور را بگو",
+			},
+			Responses: []string{
+				"واحد پول %s، %s است",
+			},
+			Replacer: CurrencyReplacer,
+		},
+
+		{
+			Tag: MathTag,
+			Patterns: []string{
+				"حاصل را حساب کن",
+				"محاسبه کن",
+				"جواب چند می‌شود",
+			},
+			Responses: []string{
+				"نتیجه %s است",
+				"می‌شود %s",
+			},
+			Replacer: MathReplacer,
+		},
+
+		{
+			Tag: GenresTag,
+			Patterns: []string{
+				"ژانرهای مورد علاقه من کمدی و ترسناک است",
+				"من فیلم‌های کمدی و ترسناک را دوست دارم",
+				"فیلم‌های جنگی را دوست دارم",
+				"فیلم اکشن دوست دارم",
+			},
+			Responses: []string{
+				"انتخاب‌های خوبی است! این ژانرها را برایت ذخیره کردم.",
+				"باشه، این ژانرها را برایت ذخیره کردم.",
+			},
+			Replacer: GenresReplacer,
+		},
+
+		{
+			Tag: MoviesTag,
+			Patterns: []string{
+				"یک فیلم درباره پیدا کن",
+				"یک فیلم پیشنهاد بده",
+				"یک فیلم برایم پیدا کن",
+			},
+			Responses: []string{
+				"فیلم «%s» را برایت پیدا کردم که امتیازش %.02f از ۵ است",
+				"حتما، فیلم «%s» با امتیاز %.02f از ۵",
+			},
+			Replacer: MovieSearchReplacer,
+		},
+
+		{
+			Tag: MoviesAlreadyTag,
+			Patterns: []string{
+				"این فیلم را قبلا دیده‌ام",
+				"این فیلم را دیده‌ام",
+				"قبلا این فیلم را تماشا کرده‌ام",
+			},
+			Responses: []string{
+				"باشه، این یکی چطور: «%s» با امتیاز %.02f از ۵",
+			},
+			Replacer: MovieSearchReplacer,
+		},
+
+		{
+			Tag: MoviesDataTag,
+			Patterns: []string{
+				"حوصله‌ام سر رفته",
+				"نمی‌دانم چه کار کنم",
+			},
+			Responses: []string{
+				"پیشنهاد می‌کنم فیلم %s «%s» را ببینی که امتیازش %.02f از ۵ است",
+			},
+			Replacer: MovieSearchFromInformationReplacer,
+		},
+
+		{
+			Tag: NameGetterTag,
+			Patterns: []string{
+				"اسم من را می‌دانی؟",
+				"اسمم چیست؟",
+			},
+			Responses: []string{
+				"اسم تو %s است!",
+			},
+			Replacer: NameGetterReplacer,
+		},
+
+		{
+			Tag: NameSetterTag,
+			Patterns: []string{
+				"اسم من ",
+				"اسمم ",
+				"نام من ",
+			},
+			Responses: []string{
+				"عالی! سلام %s",
+			},
+			Replacer: NameSetterReplacer,
+		},
+
+		{
+			Tag: RandomTag,
+			Patterns: []string{
+				"یک عدد تصادفی بده",
+				"یک عدد تصادفی بساز",
+			},
+			Responses: []string{
+				"عدد %s است",
+			},
+			Replacer: RandomNumberReplacer,
+		},
+
+		{
+			Tag: JokesTag,
+			Patterns: []string{
+				"یک جوک بگو",
+				"مرا بخندان",
+			},
+			Responses: []string{
+				"بفرما، %s",
+			},
+			Replacer: JokesReplacer,
+		},
+		{
+			Tag: AdvicesTag,
+			Patterns: []string{
+				"یک نصیحت بگو",
+				"به من توصیه کن",
+			},
+			Responses: []string{
+				"بفرما، %s",
+				"خوب گوش کن، %s",
+			},
+			Replacer: AdvicesReplacer,
+		},
+	})
+}
@@ -0,0 +1,260 @@
+package training
+
+import (
+	"strings"
+
+	util "marboris/nout/utils"
+)
+
+// ArticleCountriesFrench returns the name of the country after "de", with
+// its article: "de la France", "du Canada", "des États-Unis", "de l'Iran".
+func ArticleCountriesFrench(name string) string {
+	switch {
+	case util.Contains([]string{"Chypre", "Cuba", "Haïti", "Israël", "Madagascar", "Malte", "Monaco", "Singapour", "Taïwan"}, name):
+		if strings.ContainsAny(name[:1], "AEIOUHaeiouh") || strings.HasPrefix(name, "Î") {
+			return "d'" + name
+		}
+
+		return "de " + name
+	case util.Contains([]string{"États-Unis", "Pays-Bas", "Émirats arabes unis", "Philippines", "Comores", "Maldives", "Seychelles"}, name):
+		return "des " + name
+	case strings.ContainsAny(name[:1], "AEIOUaeiou") || strings.HasPrefix(name, "É") || strings.HasPrefix(name, "Î"):
+		return "de l'" + name
+	case strings.HasSuffix(name, "e") &&
+		!util.Contains([]string{"Belize", "Cambodge", "Mexique", "Mozambique", "Suriname", "Zimbabwe"}, name):
+		return "de la " + name
+	}
+
+	return "du " + name
+}
+
+func init() {
+	Locales = append(Locales, Locale{
+		Tag:  "fr",
+		Name: "french",
+	})
+
+	ArticleCountriesm["fr"] = ArticleCountriesFrench
+
+	Conjunctions["fr"] = "et"
+	SpellingDistances["fr"] = 2
+	Elisions["fr"] = []string{"c", "d", "j", "l", "m", "n", "s", "t", "qu", "jusqu", "lorsqu", "puisqu"}
+	ClarificationOrdinals["fr"] = [][]string{
+		{"premier", "première", "1"},
+		{"deuxième", "second", "seconde", "2"},
+	}
+
+	MoviesGenres["fr"] = []string{
+		"Action", "Aventure", "Animation", "Enfants", "Comédie", "Policier", "Documentaire", "Drame", "Fantastique",
+		"Film noir", "Horreur", "Comédie musicale", "Mystère", "Romance", "Science-fiction", "Thriller", "Guerre",
+		"Western",
+	}
+	MathDecimals["fr"] = `(\d+( |-)décimale(s)?)|(nombre (de )?décimale(s)? (est )?\d+)`
+
+	DefaultStopWords["fr"] = []string{
+		"le", "la", "les", "l'", "un", "une", "des", "du", "de", "d'", "et", "ou", "à", "au", "aux",
+		"ce", "cet", "cette", "en", "est", "sur", "pour", "par", "avec", "dans", "se", "s'", "ne", "pas",
+	}
+
+	DefaultMessages["fr"] = []Message{
+		{Tag: OutOfScopeTag, Messages: []string{"Désolé, je ne comprends pas"}},
+		{Tag: ClarificationTag, Messages: []string{"Vouliez-vous dire %s ou %s ?"}},
+		{Tag: "no country", Messages: []string{"Je n'ai trouvé aucun pays"}},
+		{Tag: "no genres", Messages: []string{"Je n'ai trouvé aucun genre"}},
+		{Tag: "no genres saved", Messages: []string{"Vous ne m'avez pas encore dit vos genres préférés"}},
+		{Tag: "don't know name", Messages: []string{"Je ne connais pas encore votre nom"}},
+		{Tag: "no name", Messages: []string{"Je n'ai trouvé aucun nom"}},
+		{Tag: "math not valid", Messages: []string{"Ce calcul n'est pas valide"}},
+		{Tag: "no random range", Messages: []string{"Il me faut deux nombres pour l'intervalle"}},
+		{Tag: "no jokes", Messages: []string{"Je n'ai pas trouvé de blague"}},
+		{Tag: "no advices", Messages: []string{"Je n'ai pas trouvé de conseil"}},
+	}
+
+	DefaultIntents["fr"] = []Intent{
+		{
+			Tag:       "hello",
+			Patterns:  []string{"Bonjour", "Salut", "Coucou", "Bonsoir", "Salut mon ami"},
+			Responses: []string{"Bonjour !", "Salut !"},
+		},
+		{
+			Tag:       "goodbye",
+			Patterns:  []string{"Au revoir", "À plus tard", "À bientôt", "Je dois partir", "Salut, je m'en vais"},
+			Responses: []string{"Au revoir !"},
+		},
+		{
+			Tag:       "thanks",
+			Patterns:  []string{"Merci", "Merci beaucoup", "C'est gentil", "Merci bien"},
+			Responses: []string{"De rien"},
+		},
+	}
+
+	RegisterModules("fr", []Modulem{
+		{
+			Tag: AreaTag,
+			Patterns: []string{
+				"Quelle est la superficie de ",
+				"Donne-moi la superficie de ",
+			},
+			Responses: []string{
+				"La superficie %s est de %gkm²",
+			},
+			Replacer: AreaReplacer,
+		},
+
+		{
+			Tag: CapitalTag,
+			Patterns: []string{
+				"Quelle est la capitale de ",
+				"C'est quoi la capitale de ",
+				"Donne-moi la capitale de ",
+			},
+			Responses: []string{
+				"La capitale %s est %s",
+			},
+			Replacer: CapitalReplacer,
+		},
+
+		{
+			Tag: CurrencyTag,
+			Patterns: []string{
+				"Quelle monnaie est utilisée en ",
+				"Donne-moi la monnaie de ",
+				"Quelle est la devise de ",
+			},
+			Responses: []string{
+				"La monnaie %s est %s",
+			},
+			Replacer: CurrencyReplacer,
+		},
+
+		{
+			Tag: MathTag,
+			Patterns: []string{
+				"Donne-moi le résultat de ",
+				"Calcule ",
+			},
+			Responses: []string{
+				"Le résultat est %s",
+				"Ça fait %s",
+			},
+			Replacer: MathReplacer,
+		},
+
+		{
+			Tag: GenresTag,
+			Patterns: []string{
+				"Mes genres de films préférés sont la comédie et l'horreur",
+				"J'aime les genres comédie et horreur",
+				"J'aime les films de guerre",
+				"J'aime les films d'action",
+			},
+			Responses: []string{
+				"Bons choix ! J'ai enregistré ces genres de films dans votre client.",
+				"Compris, j'ai enregistré ces genres de films dans votre client.",
+			},
+			Replacer: GenresReplacer,
+		},
+
+		{
+			Tag: MoviesTag,
+			Patterns: []string{
+				"Trouve-moi un film sur",
+				"Donne-moi un film de",
+				"Trouve-moi un film",
+			},
+			Responses: []string{
+				"J'ai trouvé le film « %s » pour vous, qui est noté %.02f/5",
+				"Bien sûr, voici le film « %s », qui est noté %.02f/5",
+			},
+			Replacer: MovieSearchReplacer,
+		},
+
+		{
+			Tag: MoviesAlreadyTag,
+			Patterns: []string{
+				"J'ai déjà vu ce film",
+				"J'ai déjà regardé ce film",
+				"Oh, j'ai déjà vu ce film",
+			},
+			Responses: []string{
+				"Ah d'accord, en voici un autre : « %s », noté %.02f/5",
+			},
+			Replacer: MovieSearchReplacer,
+		},
+
+		{
+			Tag: MoviesDataTag,
+			Patterns: []string{
+				"Je m'ennuie",
+				"Je ne sais pas quoi faire",
+			},
+			Responses: []string{
+				"Je vous propose de regarder le film %s « %s », noté %.02f/5",
+			},
+			Replacer: MovieSearchFromInformationReplacer,
+		},
+
+		{
+			Tag: NameGetterTag,
+			Patterns: []string{
+				"Connais-tu mon nom ?",
+				"Comment je m'appelle ?",
+			},
+			Responses: []string{
+				"Vous vous appelez %s !",
+			},
+			Replacer: NameGetterReplacer,
+		},
+
+		{
+			Tag: NameSetterTag,
+			Patterns: []string{
+				"Je m'appelle ",
+				"Mon nom est ",
+				"Tu peux m'appeler ",
+			},
+			Responses: []string{
+				"Super ! Bonjour %s",
+			},
+			Replacer: NameSetterReplacer,
+		},
+
+		{
+			Tag: RandomTag,
+			Patterns: []string{
+				"Donne-moi un nombre aléatoire",
+				"Génère un nombre aléatoire",
+			},
+			Responses: []string{
+				"Le nombre est %s",
+			},
+			Replacer: RandomNumberReplacer,
+		},
+
+		{
+			Tag: JokesTag,
+			Patterns: []string{
+				"Raconte-moi une blague",
+				"Fais-moi rire",
+			},
+			Responses: []string{
+				"Voici, %s",
+				"En voici une, %s",
+			},
+			Replacer: JokesReplacer,
+		},
+		{
+			Tag: AdvicesTag,
+			Patterns: []string{
+				"Donne-moi un conseil",
+				"Conseille-moi",
+			},
+			Responses: []string{
+				"Voici, %s",
+				"En voici un, %s",
+				"Écoute bien, %s",
+			},
+			Replacer: AdvicesReplacer,
+		},
+	})
+}
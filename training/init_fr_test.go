@@ -0,0 +1,20 @@
+package training
+
+import "testing"
+
+func TestArticleCountriesFrench(t *testing.T) {
+	for name, expected := range map[string]string{
+		"France":     "de la France",
+		"Canada":     "du Canada",
+		"Belize":     "du Belize",
+		"Mexique":    "du Mexique",
+		"Iran":       "de l'Iran",
+		"États-Unis": "des États-Unis",
+		"Cuba":       "de Cuba",
+		"Israël":     "d'Israël",
+	} {
+		if article := ArticleCountriesFrench(name); article != expected {
+			t.Errorf("%q gave %q instead of %q", name, article, expected)
+		}
+	}
+}
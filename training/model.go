@@ -0,0 +1,685 @@
+package training
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	matrix "marboris/nout/matrix"
+	util "marboris/nout/utils"
+)
+
+// ModelFile is where CreateModel saves the model bundle.
+func ModelFile() string {
+	return filepath.Join(os.TempDir(), "Marboris-Model.json")
+}
+
+func NewModel(locale, modelType string) (model Model, documents []Document) {
+	pipeline := DefaultPipeline
+	pipeline.Spelling.MaxDistance = SpellingDistances[locale]
+
+	return NewModelWith(locale, modelType, pipeline)
+}
+
+// NewModelWith is NewModel with the pipeline the documents are analyzed with,
+// such as the pipeline of a saved model.
+func NewModelWith(locale, modelType string, pipeline Pipeline) (model Model, documents []Document) {
+	words, classes, documents := organize(locale, &pipeline)
+
+	model = Model{
+		Locale:    locale,
+		Type:      modelType,
+		Words:     words,
+		Classes:   classes,
+		Threshold: DefaultThreshold,
+		Pipeline:  pipeline,
+		Vectorizer: Vectorizer{
+			Type:       DefaultVectorizer,
+			Dimensions: HashingDimensions,
+		},
+	}
+
+	model.Pipeline.Spelling.Index(knownWords(documents, model.Pipeline))
+
+	return model, documents
+}
+
+func (model Model) vocabulary() Vocabulary {
+	vocabulary := NewVocabulary(model.Words)
+	vocabulary.Vectorizer = model.Vectorizer
+
+	return vocabulary
+}
+
+// vectorize fits the vectorizer of the model on the training documents and
+// returns their vectors.
+func (model *Model) vectorize(documents []Document) (inputs, outputs [][]float64) {
+	model.Vectorizer.Fit(model.Words, documents)
+
+	return VectorizeWith(model.vocabulary(), model.Classes, documents)
+}
+
+// Train fits the model on the documents, resampled as configured by
+// Balancing and weighted by tag when ClassWeighting is set. Multi-label
+// models also learn from combinations of the documents.
+func (model *Model) Train(documents []Document, rate float64, hiddensNodes int) error {
+	return model.TrainIterations(documents, rate, hiddensNodes, 0)
+}
+
+// TrainIterations is Train with the number of iterations of the network,
+// GRU and embedding models, which default to NetworkIterations,
+// GRUIterations and EmbeddingIterations when it is 0.
+func (model *Model) TrainIterations(documents []Document, rate float64, hiddensNodes, iterations int) error {
+	iterationsOr := func(defaultIterations int) int {
+		if iterations > 0 {
+			return iterations
+		}
+
+		return defaultIterations
+	}
+
+	if model.MultiLabel {
+		if model.Type != NetworkModel && model.Type != EmbeddingModel {
+			return fmt.Errorf("the %q model type has no independent outputs for multi-label mode", model.Type)
+		}
+
+		documents = append(documents, CombineDocuments(model.random, model.Locale, documents, MultiLabelCombinations)...)
+	}
+
+	balanced := BalanceDocuments(model.random, documents, Balancing)
+	if Balancing != NoBalancing || ClassWeighting {
+		fmt.Print(ReportDataset(documents, balanced))
+	}
+	documents = balanced
+
+	var weights []float64
+	if ClassWeighting {
+		weights = ClassWeights(documents)
+	}
+
+	switch model.Type {
+	case GRUModel:
+		sequences, outputs := Sequences(model.Words, model.Classes, documents)
+		gru := matrix.CreateGRUFrom(
+			model.random, model.Locale, rate, len(model.Words), GRUDimensions, hiddensNodes, len(model.Classes),
+		)
+		gru.SampleWeights = weights
+		gru.Train(sequences, outputs, iterationsOr(GRUIterations))
+		model.GRU = &gru
+	case EmbeddingModel:
+		sequences, outputs := Sequences(model.Words, model.Classes, documents)
+		embedding := CreateEmbedding(model.random, model.Locale, model.Words)
+		sequential := NewEmbeddingSequential(model.random, rate, embedding, hiddensNodes, len(model.Classes))
+		sequential.SampleWeights = weights
+		sequential.Train(PadSequences(sequences), outputs, iterationsOr(EmbeddingIterations))
+		model.Embedding = sequential.Values()
+		model.Pooling = embedding.Pooling
+	default:
+		classifier, err := model.newClassifier(rate, hiddensNodes, iterationsOr(NetworkIterations), weights)
+		if err != nil {
+			return err
+		}
+
+		inputs, outputs := model.vectorize(documents)
+		classifier.Train(inputs, outputs)
+		model.setClassifier(classifier)
+		if model.Type == KNNModel {
+			model.Patterns = documents
+		}
+	}
+
+	return nil
+}
+
+// newClassifier returns an untrained classifier of the type of the model,
+// for the models that read bags of words.
+func (model Model) newClassifier(rate float64, hiddensNodes, iterations int, weights []float64) (matrix.Classifier, error) {
+	switch model.Type {
+	case NetworkModel:
+		classifier := matrix.NewNetworkClassifier(model.Locale, rate, hiddensNodes, iterations)
+		classifier.SampleWeights = weights
+		classifier.Random = model.random
+		return classifier, nil
+	case NaiveBayesModel:
+		classifier := matrix.NewNaiveBayes(NaiveBayesSmoothing)
+		classifier.SampleWeights = weights
+		return classifier, nil
+	case LogisticModel:
+		classifier := matrix.NewLogisticRegression(rate, LogisticIterations, LogisticRegularization)
+		classifier.SampleWeights = weights
+		return classifier, nil
+	case KNNModel:
+		classifier := matrix.NewKNN(KNNNeighbors)
+		classifier.SampleWeights = weights
+		return classifier, nil
+	}
+
+	return nil, fmt.Errorf("unknown model type %q", model.Type)
+}
+
+// setClassifier keeps the trained classifier in the field of the bundle
+// saving it.
+func (model *Model) setClassifier(classifier matrix.Classifier) {
+	switch classifier := classifier.(type) {
+	case *matrix.NetworkClassifier:
+		model.Network = &classifier.Network
+	case *matrix.NaiveBayes:
+		model.NaiveBayes = classifier
+	case *matrix.LogisticRegression:
+		model.Logistic = classifier
+	case *matrix.KNN:
+		model.KNN = classifier
+	}
+}
+
+// Classifier returns the trained classifier of the models that read bags of
+// words, or an error for the sequence models and the bundles without one.
+func (model Model) Classifier() (matrix.Classifier, error) {
+	var classifier matrix.Classifier
+	switch model.Type {
+	case NetworkModel:
+		if model.Network != nil {
+			classifier = &matrix.NetworkClassifier{Network: *model.Network}
+		}
+	case NaiveBayesModel:
+		if model.NaiveBayes != nil {
+			classifier = model.NaiveBayes
+		}
+	case LogisticModel:
+		if model.Logistic != nil {
+			classifier = model.Logistic
+		}
+	case KNNModel:
+		if model.KNN != nil {
+			classifier = model.KNN
+		}
+	default:
+		return nil, fmt.Errorf("the %q model type has no classifier", model.Type)
+	}
+
+	if classifier == nil {
+		return nil, fmt.Errorf("the %q model is not trained", model.Type)
+	}
+
+	return classifier, nil
+}
+
+// SaveClassifier writes the classifier of the model alone, which a model of
+// the same type, words and classes reads back with LoadClassifier.
+func (model Model) SaveClassifier(fileName string) error {
+	classifier, err := model.Classifier()
+	if err != nil {
+		return err
+	}
+
+	return classifier.Save(fileName)
+}
+
+// LoadClassifier replaces the classifier of the model by the one saved in
+// fileName with SaveClassifier.
+func (model *Model) LoadClassifier(fileName string) error {
+	classifier, err := model.newClassifier(0, 0, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	err = classifier.Load(fileName)
+	if err != nil {
+		return err
+	}
+
+	model.setClassifier(classifier)
+
+	return model.Validate()
+}
+
+// Validate checks that the model has the trained weights of its type, and a
+// vectorizer that gives their inputs.
+func (model Model) Validate() error {
+	err := model.Vectorizer.Validate()
+	if err != nil {
+		return err
+	}
+
+	switch model.Type {
+	case GRUModel:
+		if model.GRU == nil {
+			return fmt.Errorf("the %q model is not trained", model.Type)
+		}
+	case EmbeddingModel:
+		if len(model.Embedding) == 0 {
+			return fmt.Errorf("the %q model is not trained", model.Type)
+		}
+	default:
+		_, err = model.Classifier()
+		if err != nil {
+			return err
+		}
+
+		if model.Type == NetworkModel && len(model.Network.Weights) > 0 {
+			inputs, size := matrix.Rows(model.Network.Weights[0]), model.vocabulary().Size()
+			if inputs != size {
+				return fmt.Errorf("the network has %d inputs but the vectors have %d values", inputs, size)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (model Model) Save(fileName string) {
+	outF, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o777) // 0666 for windows support TODO()
+	if err != nil {
+		panic("Failed to save the model to " + fileName + ".")
+	}
+	defer outF.Close()
+
+	encoder := json.NewEncoder(outF)
+	err = encoder.Encode(model)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func LoadModel(fileName string) (model Model, err error) {
+	inF, err := os.Open(fileName)
+	if err != nil {
+		return Model{}, err
+	}
+	defer inF.Close()
+
+	err = json.NewDecoder(inF).Decode(&model)
+	if err != nil {
+		return Model{}, err
+	}
+
+	err = model.Validate()
+	if err != nil {
+		return Model{}, err
+	}
+
+	model.Pipeline.Spelling.Index(model.Pipeline.Spelling.Words)
+
+	return model, nil
+}
+
+// Scores returns the output of the model for every class of model.Classes,
+// calibrated when the model has a temperature.
+func (model Model) Scores(content string) []float64 {
+	scores := model.RawScores(content)
+	if model.Temperature <= 0 {
+		return scores
+	}
+
+	return model.calibrate(scores, model.Temperature)
+}
+
+// sentence returns the content as a sentence analyzed with the settings of
+// the model.
+func (model Model) sentence(content string) Sentence {
+	return Sentence{
+		Locale:   model.Locale,
+		Content:  content,
+		Pipeline: &model.Pipeline,
+	}
+}
+
+func (model Model) RawScores(content string) []float64 {
+	sentence := model.sentence(content)
+	sentence.arrange()
+
+	switch model.Type {
+	case GRUModel:
+		return model.GRU.Predict(sentence.Sequence(model.Words))
+	case EmbeddingModel:
+		sequence := PadSequences([][]int{sentence.Sequence(model.Words)})[0]
+		return LoadEmbeddingSequential(model.Embedding, model.Pooling).Predict(sequence)
+	default:
+		classifier, err := model.Classifier()
+		if err != nil {
+			return make([]float64, len(model.Classes))
+		}
+
+		return classifier.Predict(model.vocabulary().Bag(sentence))
+	}
+}
+
+// Predict returns a prediction for every class, the most likely first.
+func (model Model) Predict(content string) []Prediction {
+	return rankPredictions(model.Classes, model.Scores(content))
+}
+
+func rankPredictions(classes []string, scores []float64) (predictions []Prediction) {
+	for i, score := range scores {
+		predictions = append(predictions, Prediction{
+			Tag:   classes[i],
+			Score: score,
+		})
+	}
+
+	sort.SliceStable(predictions, func(i, j int) bool {
+		return predictions[i].Score > predictions[j].Score
+	})
+
+	return predictions
+}
+
+// Decide returns the best prediction, or OutOfScopeTag when it is under the
+// confidence threshold or too close to the second best.
+func (threshold Threshold) Decide(predictions []Prediction) Prediction {
+	if len(predictions) == 0 {
+		return Prediction{Tag: OutOfScopeTag}
+	}
+
+	best := predictions[0]
+	if best.Score < threshold.Confidence {
+		return Prediction{Tag: OutOfScopeTag, Score: best.Score}
+	}
+
+	if len(predictions) > 1 && best.Score-predictions[1].Score < threshold.Margin {
+		return Prediction{Tag: OutOfScopeTag, Score: best.Score}
+	}
+
+	return best
+}
+
+// Ambiguous tells whether the two best predictions are both confident but
+// too close to pick one.
+func (threshold Threshold) Ambiguous(predictions []Prediction) bool {
+	return len(predictions) > 1 &&
+		predictions[1].Score >= threshold.Confidence &&
+		predictions[0].Score-predictions[1].Score < threshold.Margin
+}
+
+func (model Model) Classify(content string) Prediction {
+	return model.Threshold.Decide(model.Predict(content))
+}
+
+func (model Model) TopK(content string, k int) []Prediction {
+	predictions := model.Predict(content)
+	if k < len(predictions) {
+		predictions = predictions[:k]
+	}
+
+	return predictions
+}
+
+// Reply classifies the content and answers with the replacer of the matching
+// module, a response of the matching intent, or the message of OutOfScopeTag.
+// When the two best intents are too close it asks the user to choose, and the
+// next message of the same token answers that question.
+func (model Model) Reply(content, token string) (string, string) {
+	if model.MultiLabel {
+		var tags []string
+		for _, prediction := range model.Labels(content) {
+			tags = append(tags, prediction.Tag)
+		}
+
+		return RespondAll(model.Locale, tags, content, token)
+	}
+
+	if clarification, exists := takeClarification(token); exists {
+		prediction, resolved := model.Resolve(clarification, content)
+		if resolved {
+			return Respond(model.Locale, prediction.Tag, clarification.Content, token)
+		}
+	}
+
+	predictions := model.Predict(content)
+	if model.Threshold.Ambiguous(predictions) {
+		clarificationsMutex.Lock()
+		clarifications[token] = Clarification{
+			Content:     content,
+			Predictions: predictions[:2],
+		}
+		clarificationsMutex.Unlock()
+
+		return ClarificationTag, model.ClarificationMessage(predictions[0], predictions[1])
+	}
+
+	return Respond(model.Locale, model.Threshold.Decide(predictions).Tag, content, token)
+}
+
+// takeClarification returns the clarification waiting for the answer of the
+// token and forgets it.
+func takeClarification(token string) (Clarification, bool) {
+	clarificationsMutex.Lock()
+	defer clarificationsMutex.Unlock()
+
+	clarification, exists := clarifications[token]
+	delete(clarifications, token)
+
+	return clarification, exists
+}
+
+func (model Model) ClarificationMessage(first, second Prediction) string {
+	message := GetMessageu(model.Locale, ClarificationTag)
+	if !strings.Contains(message, "%s") {
+		message = "Did you mean %s or %s?"
+	}
+
+	return fmt.Sprintf(message, first.Tag, second.Tag)
+}
+
+// Resolve finds which intent of the clarification the answer chooses, by its
+// exact tag, by an ordinal such as "the first one", or by classifying the
+// answer.
+func (model Model) Resolve(clarification Clarification, answer string) (Prediction, bool) {
+	answer = strings.ToLower(answer)
+
+	for _, prediction := range clarification.Predictions {
+		if strings.Trim(answer, " .,!?") == strings.ToLower(prediction.Tag) {
+			return prediction, true
+		}
+	}
+
+	for i, ordinals := range ClarificationOrdinals[model.Locale] {
+		if i >= len(clarification.Predictions) {
+			break
+		}
+
+		for _, word := range strings.Fields(answer) {
+			if util.Contains(ordinals, strings.Trim(word, ".,!?")) {
+				return clarification.Predictions[i], true
+			}
+		}
+	}
+
+	prediction := model.Classify(answer)
+	for _, candidate := range clarification.Predictions {
+		if candidate.Tag == prediction.Tag {
+			return candidate, true
+		}
+	}
+
+	return Prediction{}, false
+}
+
+func Respond(locale, tag, content, token string) (string, string) {
+	if tag == OutOfScopeTag {
+		return tag, GetMessageu(locale, tag)
+	}
+
+	if normalizer, exists := Normalizers[locale]; exists {
+		content = normalizer(content)
+	}
+
+	for _, module := range GetModules(locale) {
+		if module.Tag != tag {
+			continue
+		}
+
+		response := module.Responses[rand.Intn(len(module.Responses))]
+		if module.Replacer == nil {
+			return tag, response
+		}
+
+		return module.Replacer(locale, content, response, token)
+	}
+
+	for _, intent := range GetIntents(locale) {
+		if intent.Tag != tag || len(intent.Responses) == 0 {
+			continue
+		}
+
+		return tag, intent.Responses[rand.Intn(len(intent.Responses))]
+	}
+
+	return OutOfScopeTag, GetMessageu(locale, OutOfScopeTag)
+}
+
+// Accuracy returns the share of documents whose best prediction is their tag.
+func (model Model) Accuracy(documents []Document) float64 {
+	if len(documents) == 0 {
+		return 0
+	}
+
+	var correct int
+	for _, document := range documents {
+		if model.Predict(document.Sentence.Content)[0].Tag == document.Tag {
+			correct++
+		}
+	}
+
+	return float64(correct) / float64(len(documents))
+}
+
+// Nearest returns the k training patterns of a kNN model most similar to
+// the content.
+func (model Model) Nearest(content string, k int) (patterns []NearestPattern) {
+	if model.KNN == nil {
+		return nil
+	}
+
+	sentence := model.sentence(content)
+	sentence.arrange()
+
+	for _, neighbor := range model.KNN.Nearest(model.vocabulary().Bag(sentence), k) {
+		document := model.Patterns[neighbor.Index]
+
+		patterns = append(patterns, NearestPattern{
+			Pattern:    document.Sentence.Content,
+			Tag:        document.Tag,
+			Similarity: neighbor.Similarity,
+		})
+	}
+
+	return
+}
+
+// NearestPatterns indexes every pattern of the locale to find the k most
+// similar to the content, whatever model is used to classify it.
+func NearestPatterns(locale, content string, k int) []NearestPattern {
+	model, documents := NewModel(locale, KNNModel)
+	model.Train(documents, 0, 0)
+
+	return model.Nearest(content, k)
+}
+
+// CompareModels trains a model of every type on the same split of the
+// intents of the locale and returns their accuracy on the validation
+// documents.
+func CompareModels(locale string, modelTypes []string, rate float64, hiddensNodes int) (map[string]float64, error) {
+	base, documents := NewModel(locale, "")
+	training, validation := SplitDocuments(documents, ComparisonSplit)
+
+	accuracies := map[string]float64{}
+	for _, modelType := range modelTypes {
+		model := base
+		model.Type = modelType
+
+		err := model.Train(training, rate, hiddensNodes)
+		if err != nil {
+			return nil, err
+		}
+
+		accuracies[modelType] = model.Accuracy(validation)
+	}
+
+	fmt.Printf("%-12s %9s\n", "Model", "Accuracy")
+	for _, modelType := range modelTypes {
+		fmt.Printf("%-12s %9.3f\n", modelType, accuracies[modelType])
+	}
+
+	return accuracies, nil
+}
+
+// SerializeOutOfScope reads the sentences, one per line, that no intent of
+// the locale should accept.
+func SerializeOutOfScope(locale string) (sentences []string, err error) {
+	bytes, err := os.ReadFile(util.GetResDir("locales", "out_of_scope.txt", locale))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sentence := range strings.Split(string(bytes), "\n") {
+		if strings.TrimSpace(sentence) == "" {
+			continue
+		}
+
+		sentences = append(sentences, sentence)
+	}
+
+	return
+}
+
+// EvaluateOutOfScope classifies sentences that match no intent and counts
+// those the model wrongly accepts.
+func (model Model) EvaluateOutOfScope(sentences []string) (report OutOfScopeReport) {
+	report.AcceptedTags = map[string]int{}
+
+	for _, sentence := range sentences {
+		report.Total++
+
+		prediction := model.Classify(sentence)
+		if prediction.Tag == OutOfScopeTag {
+			continue
+		}
+
+		report.FalseAccepts++
+		report.AcceptedTags[prediction.Tag]++
+	}
+
+	if report.Total > 0 {
+		report.FalseAcceptRate = float64(report.FalseAccepts) / float64(report.Total)
+	}
+
+	return
+}
+
+func (report OutOfScopeReport) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(
+		&builder, "%d of %d out-of-scope sentences were accepted, a rate of %.3f.\n",
+		report.FalseAccepts, report.Total, report.FalseAcceptRate,
+	)
+
+	tags := make([]string, 0, len(report.AcceptedTags))
+	for tag := range report.AcceptedTags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		fmt.Fprintf(&builder, "%-30s %8d\n", tag, report.AcceptedTags[tag])
+	}
+
+	return builder.String()
+}
+
+// ReportOutOfScope prints how many out-of-scope sentences of the locale the
+// model accepts, or why there are none to evaluate.
+func (model Model) ReportOutOfScope() {
+	sentences, err := SerializeOutOfScope(model.Locale)
+	if err != nil {
+		fmt.Println("No out-of-scope sentences to evaluate:", err)
+		return
+	}
+
+	fmt.Print(model.EvaluateOutOfScope(sentences))
+}
@@ -0,0 +1,43 @@
+package training
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestModelClassifierSaveLoad(t *testing.T) {
+	model, documents := NewModel("fr", NaiveBayesModel)
+	if err := model.Train(documents, 0.1, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	fileName := filepath.Join(t.TempDir(), "classifier.json")
+	if err := model.SaveClassifier(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := model
+	loaded.NaiveBayes = nil
+	if err := loaded.LoadClassifier(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, document := range documents {
+		content := document.Sentence.Content
+		if !reflect.DeepEqual(model.RawScores(content), loaded.RawScores(content)) {
+			t.Errorf("%q is scored differently after loading the classifier", content)
+		}
+	}
+}
+
+func TestLoadModelInvalid(t *testing.T) {
+	for _, model := range []Model{{Type: "bogus"}, {Type: NetworkModel}, {Type: GRUModel}} {
+		fileName := filepath.Join(t.TempDir(), "model.json")
+		model.Save(fileName)
+
+		if _, err := LoadModel(fileName); err == nil {
+			t.Errorf("the %q model without weights was loaded", model.Type)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package training
+
+import (
+	"sort"
+	"strings"
+
+	matrix "marboris/nout/matrix"
+	util "marboris/nout/utils"
+)
+
+// CombineDocuments joins the patterns of two random documents of different
+// intents with the conjunction of the locale, the result having both tags.
+func CombineDocuments(random *matrix.Random, locale string, documents []Document, count int) (combinations []Document) {
+	conjunction, exists := Conjunctions[locale]
+	if !exists {
+		conjunction = Conjunctions["en"]
+	}
+
+	if tags, _ := countTags(documents); len(tags) < 2 {
+		return nil
+	}
+
+	for len(combinations) < count {
+		first := documents[random.Intn(len(documents))]
+		second := documents[random.Intn(len(documents))]
+		if first.Tag == second.Tag || util.Contains(first.Tags, second.Tag) {
+			continue
+		}
+
+		combinations = append(combinations, Document{
+			Sentence: Sentence{
+				Locale:  locale,
+				Content: first.Sentence.Content + " " + conjunction + " " + second.Sentence.Content,
+			},
+			Tag:  first.Tag,
+			Tags: append(append([]string{}, first.Tags...), second.Tag),
+		})
+	}
+
+	return
+}
+
+// FitThresholds picks for every class the threshold that maximizes its F1
+// score on the validation documents, or LabelThreshold for the classes
+// without any.
+func (model *Model) FitThresholds(validation []Document) {
+	scores := make([][]float64, len(validation))
+	for i, document := range validation {
+		scores[i] = model.RawScores(document.Sentence.Content)
+	}
+
+	model.Thresholds = make([]float64, len(model.Classes))
+	for j, class := range model.Classes {
+		model.Thresholds[j] = LabelThreshold
+
+		var best float64
+		for threshold := 0.05; threshold < 1; threshold += 0.05 {
+			var truePositives, falsePositives, falseNegatives float64
+			for i, document := range validation {
+				relevant := document.Tag == class || util.Contains(document.Tags, class)
+				predicted := scores[i][j] >= threshold
+
+				switch {
+				case relevant && predicted:
+					truePositives++
+				case predicted:
+					falsePositives++
+				case relevant:
+					falseNegatives++
+				}
+			}
+
+			if truePositives == 0 {
+				continue
+			}
+
+			f1 := 2 * truePositives / (2*truePositives + falsePositives + falseNegatives)
+			if f1 > best {
+				best = f1
+				model.Thresholds[j] = threshold
+			}
+		}
+	}
+}
+
+// Labels returns every class whose score reaches its threshold, the most
+// likely first, or OutOfScopeTag when there is none.
+func (model Model) Labels(content string) (labels []Prediction) {
+	for i, score := range model.RawScores(content) {
+		threshold := LabelThreshold
+		if i < len(model.Thresholds) {
+			threshold = model.Thresholds[i]
+		}
+
+		if score >= threshold {
+			labels = append(labels, Prediction{
+				Tag:   model.Classes[i],
+				Score: score,
+			})
+		}
+	}
+
+	if len(labels) == 0 {
+		return []Prediction{{Tag: OutOfScopeTag}}
+	}
+
+	sort.SliceStable(labels, func(i, j int) bool {
+		return labels[i].Score > labels[j].Score
+	})
+
+	return
+}
+
+// RespondAll runs the replacer or picks a response of every tag and joins the
+// answers, leaving out the tags that could not be answered.
+func RespondAll(locale string, tags []string, content, token string) (string, string) {
+	var answeredTags, responses []string
+	for _, tag := range tags {
+		answeredTag, response := Respond(locale, tag, content, token)
+		if answeredTag == OutOfScopeTag {
+			continue
+		}
+
+		answeredTags = append(answeredTags, answeredTag)
+		responses = append(responses, response)
+	}
+
+	if len(answeredTags) == 0 {
+		return OutOfScopeTag, GetMessageu(locale, OutOfScopeTag)
+	}
+
+	return strings.Join(answeredTags, ", "), strings.Join(responses, " ")
+}
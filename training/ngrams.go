@@ -0,0 +1,57 @@
+package training
+
+import (
+	"strings"
+)
+
+// CharNGramPrefix marks the character n-grams among the words of a model.
+const CharNGramPrefix = "#"
+
+// wordNGrams joins every run of n consecutive stems, for each n of the range
+// above 1, unigrams being the stems themselves.
+func wordNGrams(stems []string, minimum, maximum int) (ngrams []string) {
+	for n := max(minimum, 2); n <= maximum; n++ {
+		for i := 0; i+n <= len(stems); i++ {
+			ngrams = append(ngrams, strings.Join(stems[i:i+n], " "))
+		}
+	}
+
+	return
+}
+
+// charNGrams returns the character n-grams of every stem surrounded by "<"
+// and ">", so that the start and the end of the words stand out.
+func charNGrams(stems []string, minimum, maximum int) (ngrams []string) {
+	if minimum <= 0 {
+		return nil
+	}
+
+	for _, stem := range stems {
+		runes := []rune("<" + stem + ">")
+		for n := minimum; n <= maximum; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				ngrams = append(ngrams, CharNGramPrefix+string(runes[i:i+n]))
+			}
+		}
+	}
+
+	return
+}
+
+// ngrams returns the n-grams of the stems enabled by the pipeline of the
+// sentence.
+func (sentence Sentence) ngrams(stems []string) []string {
+	settings := sentence.pipeline().NGrams
+
+	return append(
+		wordNGrams(stems, settings.WordMin, settings.WordMax),
+		charNGrams(stems, settings.CharMin, settings.CharMax)...,
+	)
+}
+
+// features returns the stems of the sentence followed by their n-grams.
+func (sentence Sentence) features() []string {
+	stems := sentence.stem()
+
+	return append(stems, sentence.ngrams(stems)...)
+}
@@ -0,0 +1,74 @@
+package training
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var persianReplacer = strings.NewReplacer(
+	// Arabic variants of the Persian letters
+	"ي", "ی", "ى", "ی", "ئ", "ی", "ك", "ک", "ة", "ه", "ۀ", "ه", "أ", "ا", "إ", "ا", "ٱ", "ا", "ؤ", "و",
+	// Persian and Arabic-Indic digits
+	"۰", "0", "۱", "1", "۲", "2", "۳", "3", "۴", "4", "۵", "5", "۶", "6", "۷", "7", "۸", "8", "۹", "9",
+	"٠", "0", "١", "1", "٢", "2", "٣", "3", "٤", "4", "٥", "5", "٦", "6", "٧", "7", "٨", "8", "٩", "9",
+	"٫", ".", "٬", ",",
+	// Punctuation and operators
+	"؟", "?", "،", ",", "؛", ";", "×", "*", "÷", "/",
+	// Kashida
+	"\u0640", "",
+)
+
+// NormalizePersian unifies the Arabic and Persian variants of the letters
+// and digits, and removes the diacritics and the kashida.
+func NormalizePersian(text string) string {
+	text = persianReplacer.Replace(text)
+
+	return strings.Map(func(r rune) rune {
+		if (r >= '\u064b' && r <= '\u065f') || r == '\u0670' {
+			return -1
+		}
+
+		return r
+	}, text)
+}
+
+var (
+	persianPrefixes = []string{"نمی\u200c", "می\u200c", "بی\u200c"}
+	// persianSuffixes are ordered from the longest, and removed with the
+	// zero width non-joiner that may precede them.
+	persianSuffixes = []string{
+		"هایشان", "هایتان", "هایمان", "هایی", "هایم", "هایت", "هایش", "ترین", "های", "ها", "تر",
+	}
+	// persianJoinedSuffixes are only removed after a zero width non-joiner,
+	// being too common at the end of the words otherwise.
+	persianJoinedSuffixes = []string{"ای", "ام", "ات", "اش"}
+)
+
+// StemPersian is a light stemmer removing the verb prefixes joined by a zero
+// width non-joiner, and the plural, possessive and comparative suffixes, as
+// long as three letters remain.
+func StemPersian(word string) string {
+	for _, prefix := range persianPrefixes {
+		if strings.HasPrefix(word, prefix) && utf8.RuneCountInString(word)-utf8.RuneCountInString(prefix) >= 3 {
+			word = strings.TrimPrefix(word, prefix)
+			break
+		}
+	}
+
+	var suffixes []string
+	for _, suffix := range persianSuffixes {
+		suffixes = append(suffixes, "\u200c"+suffix, suffix)
+	}
+	for _, suffix := range persianJoinedSuffixes {
+		suffixes = append(suffixes, "\u200c"+suffix)
+	}
+
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(word, suffix) && utf8.RuneCountInString(word)-utf8.RuneCountInString(suffix) >= 3 {
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+
+	return strings.ReplaceAll(word, "\u200c", "")
+}
@@ -0,0 +1,48 @@
+package training
+
+import "testing"
+
+func TestStemPersian(t *testing.T) {
+	for _, test := range []struct {
+		word string
+		stem string
+	}{
+		{"کتاب‌ها", "کتاب"},
+		{"کتابها", "کتاب"},
+		{"کتاب‌هایشان", "کتاب"},
+		{"بزرگ‌ترین", "بزرگ"},
+		{"بزرگتر", "بزرگ"},
+		{"خانه‌ام", "خانه"},
+		{"می‌خواهم", "خواهم"},
+		{"نمی‌دانم", "دانم"},
+		// "ام" is only a suffix after a zero width non-joiner
+		{"سلام", "سلام"},
+		// Three letters must remain
+		{"گلها", "گلها"},
+		{"می‌رم", "میرم"},
+	} {
+		if stem := StemPersian(test.word); stem != test.stem {
+			t.Errorf("%q is stemmed to %q instead of %q", test.word, stem, test.stem)
+		}
+	}
+}
+
+func TestNormalizePersian(t *testing.T) {
+	for _, test := range []struct {
+		text   string
+		normal string
+	}{
+		{"علي", "علی"},
+		{"كتاب", "کتاب"},
+		{"مدرسة", "مدرسه"},
+		{"إيران", "ایران"},
+		{"۱۲۳ و ٤٥", "123 و 45"},
+		{"کِتابْ", "کتاب"},
+		{"کـــتاب", "کتاب"},
+		{"چطوری؟", "چطوری?"},
+	} {
+		if normal := NormalizePersian(test.text); normal != test.normal {
+			t.Errorf("%q is normalized to %q instead of %q", test.text, normal, test.normal)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package training
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	util "marboris/nout/utils"
+)
+
+const (
+	NormalizeStage  = "normalize"
+	TokenizeStage   = "tokenize"
+	DictionaryStage = "dictionary"
+	StopWordStage   = "stopwords"
+	SpellingStage   = "spelling"
+	StemStage       = "stem"
+)
+
+// Stage transforms the tokens of a sentence. Until TokenizeStage runs, the
+// whole text is a single token.
+type Stage func(pipeline Pipeline, locale string, tokens []string) []string
+
+var (
+	DefaultStages = []string{
+		NormalizeStage, TokenizeStage, DictionaryStage, StopWordStage, SpellingStage, StemStage,
+	}
+
+	Stages = map[string]Stage{
+		NormalizeStage: func(_ Pipeline, locale string, tokens []string) []string {
+			for i, token := range tokens {
+				tokens[i] = Normalize(locale, token)
+			}
+
+			return tokens
+		},
+		TokenizeStage: func(_ Pipeline, locale string, tokens []string) (words []string) {
+			for _, token := range tokens {
+				words = append(words, Tokenize(locale, token)...)
+			}
+
+			return
+		},
+		DictionaryStage: func(_ Pipeline, locale string, tokens []string) []string {
+			return ReplaceWords(locale, tokens)
+		},
+		StopWordStage: func(pipeline Pipeline, locale string, tokens []string) []string {
+			return pipeline.StopWords.Remove(locale, tokens)
+		},
+		SpellingStage: func(pipeline Pipeline, _ string, tokens []string) []string {
+			return pipeline.Spelling.Correct(tokens)
+		},
+		StemStage: func(_ Pipeline, locale string, tokens []string) []string {
+			if stemmer, exists := Stemmers[locale]; exists {
+				stems := make([]string, len(tokens))
+				for i, token := range tokens {
+					stems[i] = stemmer(token)
+				}
+
+				return stems
+			}
+
+			stems, err := stemWords(stemmerLanguage(locale), tokens)
+			if err != nil {
+				fmt.Println("Stemmer error", err)
+				return nil
+			}
+
+			return stems
+		},
+	}
+
+	// Stemmers replace the snowball stemmer for the locales it lacks.
+	Stemmers = map[string]func(string) string{}
+
+	// Normalizers complete Normalize for a locale, such as to unify the
+	// variants of its characters.
+	Normalizers = map[string]func(string) string{}
+
+	// Elisions are the words of a locale that lose their vowel before an
+	// apostrophe, such as the "l" of "l'été", and make a token of their own.
+	Elisions = map[string][]string{}
+)
+
+// Run applies every stage of the pipeline, DefaultStages when it has none,
+// to the content.
+func (pipeline Pipeline) Run(locale, content string) []string {
+	stages := pipeline.Stages
+	if len(stages) == 0 {
+		stages = DefaultStages
+	}
+
+	tokens := []string{content}
+	for _, name := range stages {
+		stage, exists := Stages[name]
+		if !exists {
+			fmt.Printf("Unknown pipeline stage %q\n", name)
+			continue
+		}
+
+		tokens = stage(pipeline, locale, tokens)
+	}
+
+	return tokens
+}
+
+// Normalize lowercases the text, replaces the typographic apostrophes and
+// removes the bidirectional marks, before applying the normalizer of the
+// locale.
+func Normalize(locale, text string) string {
+	text = strings.ToLower(text)
+
+	text = strings.Map(func(r rune) rune {
+		if isApostrophe(r) {
+			return '\''
+		}
+
+		if isBidiMark(r) {
+			return -1
+		}
+
+		return r
+	}, text)
+
+	if normalizer, exists := Normalizers[locale]; exists {
+		text = normalizer(text)
+	}
+
+	return text
+}
+
+// isBidiMark tells whether the rune only changes the direction of the text,
+// as found around right-to-left words.
+func isBidiMark(r rune) bool {
+	return r == '\u200e' || r == '\u200f' || (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '\u2019' || r == '\u02bc' || r == '\u2018'
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+// isEmojiRune tells whether the rune is a pictograph, or a modifier that
+// belongs to the previous one: joiners, variation selectors and skin tones.
+func isEmojiRune(r rune) bool {
+	return unicode.Is(unicode.So, r) || isEmojiModifier(r)
+}
+
+func isEmojiModifier(r rune) bool {
+	return r == '\u200d' || r == '\ufe0f' || (r >= 0x1f3fb && r <= 0x1f3ff)
+}
+
+// Tokenize splits the text into words made of letters, marks and digits.
+// Apostrophes between letters are kept, as in "don't", unless the word
+// before the apostrophe is an elision of the locale, and so are the zero
+// width non-joiners of Persian words. The separators
+// of numbers such as "3.5" or "1,000" are kept as well, and every emoji
+// becomes a token with its modifiers.
+func Tokenize(locale, text string) (tokens []string) {
+	runes := []rune(text)
+
+	var token []rune
+	flush := func() {
+		if len(token) > 0 {
+			tokens = append(tokens, string(token))
+			token = nil
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case isWordRune(r):
+			token = append(token, r)
+		case isApostrophe(r) && len(token) > 0 && i+1 < len(runes) && unicode.IsLetter(runes[i+1]):
+			if util.Contains(Elisions[locale], strings.ToLower(string(token))) {
+				token = append(token, '\'')
+				flush()
+				continue
+			}
+
+			token = append(token, '\'')
+		case r == '\u200c' && len(token) > 0 && i+1 < len(runes) && unicode.IsLetter(runes[i+1]):
+			token = append(token, r)
+		case (r == '.' || r == ',') && len(token) > 0 && unicode.IsDigit(token[len(token)-1]) &&
+			i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			token = append(token, r)
+		case isEmojiRune(r) && !isEmojiModifier(r):
+			flush()
+
+			token = append(token, r)
+			for i+1 < len(runes) && (isEmojiModifier(runes[i+1]) ||
+				(runes[i] == '\u200d' && unicode.Is(unicode.So, runes[i+1]))) {
+				i++
+				token = append(token, runes[i])
+			}
+
+			flush()
+		default:
+			flush()
+		}
+	}
+
+	flush()
+
+	return
+}
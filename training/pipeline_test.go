@@ -0,0 +1,48 @@
+package training
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	for _, test := range []struct {
+		locale string
+		text   string
+		tokens []string
+	}{
+		{"en", "don't stop", []string{"don't", "stop"}},
+		{"en", "the cats' toys", []string{"the", "cats", "toys"}},
+		{"en", "'quoted' words", []string{"quoted", "words"}},
+		{"fr", "l'horreur", []string{"l'", "horreur"}},
+		{"fr", "jusqu'au bout", []string{"jusqu'", "au", "bout"}},
+		{"en", "l'horreur", []string{"l'horreur"}},
+		{"en", "3.5 and 1,000 and 2.", []string{"3.5", "and", "1,000", "and", "2"}},
+		{"en", "a, b", []string{"a", "b"}},
+		{"en", "great👍🏽 job😀😀", []string{"great", "👍🏽", "job", "😀", "😀"}},
+		{"en", "👨‍👩‍👧 family", []string{"👨‍👩‍👧", "family"}},
+		{"fa", "می‌خواهم کتاب‌ها", []string{"می‌خواهم", "کتاب‌ها"}},
+		{"fa", "کتاب‌ ها", []string{"کتاب", "ها"}},
+	} {
+		tokens := Tokenize(test.locale, test.text)
+		if !reflect.DeepEqual(tokens, test.tokens) {
+			t.Errorf("%q is split into %q instead of %q", test.text, tokens, test.tokens)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for _, test := range []struct {
+		locale string
+		text   string
+		normal string
+	}{
+		{"en", "Don’t", "don't"},
+		{"en", "‏Hello‎", "hello"},
+		{"fa", "كتاب‏ي", "کتابی"},
+	} {
+		if normal := Normalize(test.locale, test.text); normal != test.normal {
+			t.Errorf("%q is normalized to %q instead of %q", test.text, normal, test.normal)
+		}
+	}
+}
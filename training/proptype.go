@@ -1,67 +1,51 @@
 package training
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
-
-	"github.com/tebeka/snowball"
-	util "marboris/nout/utils"
 )
 
-func (sentence Sentence) WordsBag(words []string) (bag []float64) {
-	for _, word := range words {
-
-		var valueToAppend float64
-		if util.Contains(sentence.stem(), word) {
-			valueToAppend = 1
-		}
-
-		bag = append(bag, valueToAppend)
-	}
-
-	return bag
+// WordsBag indexes words on every call, Vocabulary.Bag should be preferred
+// to vectorize several sentences.
+func (sentence Sentence) WordsBag(words []string) []float64 {
+	return NewVocabulary(words).Bag(sentence)
 }
 
-func (sentence Sentence) stem() (tokenizeWords []string) {
-	locale := GetTagByName(sentence.Locale)
-
-	if locale == "" {
-		locale = "english"
-	}
-
-	tokens := sentence.tokenize()
+// Sequence returns the index in words of every stem of the sentence, in
+// order.
+func (sentence Sentence) Sequence(words []string) []int {
+	return NewVocabulary(words).Sequence(sentence)
+}
 
-	stemmer, err := snowball.New(locale)
-	if err != nil {
-		fmt.Println("Stemmer error", err)
-		return
-	}
+// stemmerLanguage returns the snowball language of the locale tag, which is
+// the name of the locale.
+func stemmerLanguage(locale string) string {
+	language := GetNameByTag(locale)
 
-	for _, tokenizeWord := range tokens {
-		word := stemmer.Stem(tokenizeWord)
-		tokenizeWords = append(tokenizeWords, word)
+	if language == "" {
+		language = "english"
 	}
 
-	return
+	return language
 }
 
-func (sentence Sentence) tokenize() (tokens []string) {
-	tokens = strings.Fields(sentence.Content)
-
-	for i, token := range tokens {
-		tokens[i] = strings.ToLower(token)
+func (sentence Sentence) pipeline() Pipeline {
+	if sentence.Pipeline != nil {
+		return *sentence.Pipeline
 	}
 
-	tokens = removeStopWords(sentence.Locale, tokens)
+	return DefaultPipeline
+}
 
-	return
+// stem runs the pipeline of the sentence, DefaultPipeline when it has none.
+func (sentence Sentence) stem() []string {
+	return sentence.pipeline().Run(sentence.Locale, sentence.Content)
 }
 
 func (sentence *Sentence) arrange() {
-	punctuationRegex := regexp.MustCompile(`[a-zA-Z]( )?(\.|\?|!|¿|¡)`)
+	punctuationRegex := regexp.MustCompile(`\p{L}( )?(\.|\?|!|¿|¡)`)
 	sentence.Content = punctuationRegex.ReplaceAllStringFunc(sentence.Content, func(s string) string {
-		punctuation := regexp.MustCompile(`(\.|\?|!)`)
+		punctuation := regexp.MustCompile(`(\.|\?|!|¿|¡)`)
 		return punctuation.ReplaceAllString(s, "")
 	})
 
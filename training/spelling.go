@@ -0,0 +1,62 @@
+package training
+
+import (
+	"sort"
+	"unicode"
+
+	"marboris/nout/fuzzy"
+)
+
+// knownWords returns the sorted tokens of the documents as they are before
+// stemming, which the spelling correction picks from.
+func knownWords(documents []Document, pipeline Pipeline) (words []string) {
+	pipeline = pipeline.Without(StemStage).Without(SpellingStage)
+
+	seen := map[string]bool{}
+	for _, document := range documents {
+		for _, token := range pipeline.Run(document.Sentence.Locale, document.Sentence.Content) {
+			if !seen[token] {
+				seen[token] = true
+				words = append(words, token)
+			}
+		}
+	}
+
+	sort.Strings(words)
+
+	return
+}
+
+// Index records the known words and builds their tree, which is not saved
+// and must be built again after loading the settings.
+func (settings *SpellingSettings) Index(words []string) {
+	settings.Words = words
+	settings.tree = fuzzy.NewBKTree(words)
+}
+
+// Correct replaces the unknown tokens made of letters by the closest known
+// word, within MaxDistance edits and a third of their length so that short
+// words are left alone.
+func (settings SpellingSettings) Correct(tokens []string) []string {
+	if settings.tree == nil || settings.MaxDistance <= 0 {
+		return tokens
+	}
+
+	corrected := make([]string, len(tokens))
+	for i, token := range tokens {
+		corrected[i] = token
+
+		runes := []rune(token)
+		budget := min(settings.MaxDistance, len(runes)/3)
+		if budget == 0 || !unicode.IsLetter(runes[0]) {
+			continue
+		}
+
+		word, found := settings.tree.Closest(token, budget)
+		if found {
+			corrected[i] = word
+		}
+	}
+
+	return corrected
+}
@@ -0,0 +1,43 @@
+package training
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpellingCorrect(t *testing.T) {
+	words := []string{"calculate", "movie", "name", "weather", "1234"}
+
+	for _, test := range []struct {
+		maxDistance int
+		tokens      []string
+		corrected   []string
+	}{
+		// The budget is min(MaxDistance, runes/3)
+		{2, []string{"wether", "nme", "nm"}, []string{"weather", "name", "nm"}},
+		{2, []string{"calclat"}, []string{"calculate"}},
+		{1, []string{"calclat"}, []string{"calclat"}},
+		{1, []string{"calculat"}, []string{"calculate"}},
+		{2, []string{"wthr"}, []string{"wthr"}},
+		{2, []string{"1235"}, []string{"1235"}},
+		{0, []string{"wether"}, []string{"wether"}},
+	} {
+		settings := SpellingSettings{MaxDistance: test.maxDistance}
+		settings.Index(words)
+
+		corrected := settings.Correct(test.tokens)
+		if !reflect.DeepEqual(corrected, test.corrected) {
+			t.Errorf(
+				"%q is corrected to %q instead of %q with %d edits",
+				test.tokens, corrected, test.corrected, test.maxDistance,
+			)
+		}
+	}
+}
+
+func TestSpellingCorrectNotIndexed(t *testing.T) {
+	tokens := []string{"wether"}
+	if corrected := (SpellingSettings{MaxDistance: 2}).Correct(tokens); !reflect.DeepEqual(corrected, tokens) {
+		t.Errorf("%q is corrected to %q without known words", tokens, corrected)
+	}
+}
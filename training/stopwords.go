@@ -0,0 +1,62 @@
+package training
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	util "marboris/nout/utils"
+)
+
+var (
+	stopWordsMutex = sync.Mutex{}
+	stopWords      = map[string]map[string]bool{}
+)
+
+// GetStopWords returns the stop words of the locale, read from its
+// stopwords.txt the first time only. A locale without the file has its
+// DefaultStopWords.
+func GetStopWords(locale string) map[string]bool {
+	stopWordsMutex.Lock()
+	defer stopWordsMutex.Unlock()
+
+	if words, exists := stopWords[locale]; exists {
+		return words
+	}
+
+	words := map[string]bool{}
+	list := DefaultStopWords[locale]
+	bytes, err := os.ReadFile(util.GetResDir("locales", "stopwords.txt", locale))
+	if err == nil {
+		list = strings.Split(string(bytes), "\n")
+	}
+
+	for _, word := range list {
+		word = Normalize(locale, strings.TrimSpace(word))
+		if word != "" {
+			words[word] = true
+		}
+	}
+
+	stopWords[locale] = words
+	return words
+}
+
+// Remove drops the words that are stop words of the locale, adding Extra to
+// its list and leaving out Kept.
+func (settings StopWordSettings) Remove(locale string, words []string) (kept []string) {
+	if settings.Disabled {
+		return words
+	}
+
+	registry := GetStopWords(locale)
+	for _, word := range words {
+		stopWord := (registry[word] || util.Contains(settings.Extra, word)) &&
+			!util.Contains(settings.Kept, word)
+		if !stopWord {
+			kept = append(kept, word)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,26 @@
+package training
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStopWordsRemove(t *testing.T) {
+	for _, test := range []struct {
+		settings StopWordSettings
+		words    []string
+		kept     []string
+	}{
+		// "a" is part of "la", "pas" and "avec" but is not a stop word itself
+		{StopWordSettings{}, []string{"il", "a", "la", "voiture"}, []string{"il", "a", "voiture"}},
+		{StopWordSettings{}, []string{"pa", "ave", "pas", "avec"}, []string{"pa", "ave"}},
+		{StopWordSettings{Extra: []string{"il"}}, []string{"il", "a", "la"}, []string{"a"}},
+		{StopWordSettings{Kept: []string{"pas"}}, []string{"ne", "pas", "aimer"}, []string{"pas", "aimer"}},
+		{StopWordSettings{Disabled: true}, []string{"le", "film"}, []string{"le", "film"}},
+	} {
+		kept := test.settings.Remove("fr", test.words)
+		if !reflect.DeepEqual(kept, test.kept) {
+			t.Errorf("%+v keeps %q of %q instead of %q", test.settings, kept, test.words, test.kept)
+		}
+	}
+}
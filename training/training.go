@@ -20,11 +20,15 @@ import (
 	"time"
 
 	"github.com/soudy/mathcat"
+	"marboris/nout/fuzzy"
 	matrix "marboris/nout/matrix"
 	util "marboris/nout/utils"
 )
 
 func GetUserInformation(token string) Information {
+	userInformationMutex.Lock()
+	defer userInformationMutex.Unlock()
+
 	return userInformation[token]
 }
 
@@ -41,22 +45,49 @@ func SerializeCountries() (countries []Country) {
 	return countries
 }
 
+// CountryName returns the name of the country in the locale, from the
+// dataset or else from CountryNames.
+func CountryName(country Country, locale string) (string, bool) {
+	name, exists := country.Name[locale]
+	if !exists {
+		name, exists = CountryNames[locale][country.Code]
+	}
+
+	return name, exists
+}
+
+// FindCountry returns the country whose name is in the sentence or, when
+// there is none, the country whose name is the closest within its
+// fuzzy.Budget. The names shorter than five letters must be in the sentence,
+// since most short words are a typo away from one.
 func FindCountry(locale, sentence string) Country {
+	closest, closestDistance := Country{}, -1
 	for _, country := range countries {
-		name, exists := country.Name[locale]
+		name, exists := CountryName(country, locale)
 
 		if !exists {
 			continue
 		}
 
-		if !strings.Contains(strings.ToLower(sentence), strings.ToLower(name)) {
+		if strings.Contains(strings.ToLower(sentence), strings.ToLower(name)) {
+			return country
+		}
+
+		if len([]rune(name)) < 5 {
 			continue
 		}
 
-		return country
+		distance := fuzzy.PhraseDistance(sentence, name)
+		if distance == -1 || distance > fuzzy.Budget(name, 2) {
+			continue
+		}
+
+		if closestDistance == -1 || distance < closestDistance {
+			closest, closestDistance = country, distance
+		}
 	}
 
-	return Country{}
+	return closest
 }
 
 func AreaReplacer(locale, entry, response, _ string) (string, string) {
@@ -67,7 +98,8 @@ func AreaReplacer(locale, entry, response, _ string) (string, string) {
 		return responseTag, GetMessageu(locale, responseTag)
 	}
 
-	return AreaTag, fmt.Sprintf(response, ArticleCountriesm[locale](country.Name[locale]), country.Area)
+	name, _ := CountryName(country, locale)
+	return AreaTag, fmt.Sprintf(response, ArticleCountriesm[locale](name), country.Area)
 }
 
 func CapitalReplacer(locale, entry, response, _ string) (string, string) {
@@ -79,7 +111,7 @@ func CapitalReplacer(locale, entry, response, _ string) (string, string) {
 	}
 
 	articleFunction, exists := ArticleCountriesm[locale]
-	countryName := country.Name[locale]
+	countryName, _ := CountryName(country, locale)
 	if exists {
 		countryName = articleFunction(countryName)
 	}
@@ -95,7 +127,8 @@ func CurrencyReplacer(locale, entry, response, _ string) (string, string) {
 		return responseTag, GetMessageu(locale, responseTag)
 	}
 
-	return CurrencyTag, fmt.Sprintf(response, ArticleCountriesm[locale](country.Name[locale]), country.Currency)
+	name, _ := CountryName(country, locale)
+	return CurrencyTag, fmt.Sprintf(response, ArticleCountriesm[locale](name), country.Currency)
 }
 
 func JokesReplacer(locale, entry, response, _ string) (string, string) {
@@ -194,9 +227,11 @@ func SerializeNames() (names []string) {
 	return
 }
 
+// FindName returns the first name that is a word of the sentence. Names are
+// matched exactly since most words are a typo away from one.
 func FindName(sentence string) string {
 	for _, name := range names {
-		if !strings.Contains(strings.ToLower(" "+sentence+" "), " "+name+" ") {
+		if !fuzzy.ContainsPhrase(sentence, name, 0) {
 			continue
 		}
 
@@ -206,8 +241,28 @@ func FindName(sentence string) string {
 	return ""
 }
 
+// FindSlotName returns the word that follows one of the NameSlots of the
+// locale, as "سارا" in "اسم من سارا است".
+func FindSlotName(locale, sentence string) string {
+	tokens := Tokenize(locale, Normalize(locale, sentence))
+
+	for _, slot := range NameSlots[locale] {
+		words := Tokenize(locale, Normalize(locale, slot))
+		for i := 0; i+len(words) < len(tokens); i++ {
+			if strings.Join(tokens[i:i+len(words)], " ") == strings.Join(words, " ") {
+				return tokens[i+len(words)]
+			}
+		}
+	}
+
+	return ""
+}
+
 func NameSetterReplacer(locale, entry, response, token string) (string, string) {
 	name := FindName(entry)
+	if name == "" {
+		name = FindSlotName(locale, entry)
+	}
 
 	if name == "" {
 		responseTag := "no name"
@@ -224,8 +279,51 @@ func NameSetterReplacer(locale, entry, response, token string) (string, string)
 	return NameSetterTag, fmt.Sprintf(response, name)
 }
 
+// SerializeMessages reads the messages.json of the locale, or takes its
+// DefaultMessages when the file does not exist.
+func SerializeMessages(locale string) (_messages []Message) {
+	bytes, err := os.ReadFile(util.GetResDir("locales", "messages.json", locale))
+	if errors.Is(err, os.ErrNotExist) && DefaultMessages[locale] != nil {
+		cacheMessages(locale, DefaultMessages[locale])
+		return DefaultMessages[locale]
+	}
+	if err != nil {
+		bytes = util.ReadFile(util.GetResDir("locales", "messages.json", locale))
+	}
+
+	err = json.Unmarshal(bytes, &_messages)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	cacheMessages(locale, _messages)
+
+	return _messages
+}
+
+func cacheMessages(locale string, _messages []Message) {
+	messagesMutex.Lock()
+	defer messagesMutex.Unlock()
+
+	messages[locale] = _messages
+}
+
+// GetMessages returns the messages of the locale, serialized the first time
+// only.
+func GetMessages(locale string) []Message {
+	messagesMutex.Lock()
+	_messages, exists := messages[locale]
+	messagesMutex.Unlock()
+
+	if !exists {
+		_messages = SerializeMessages(locale)
+	}
+
+	return _messages
+}
+
 func GetMessageu(locale, tag string) string {
-	for _, message := range messages[locale] {
+	for _, message := range GetMessages(locale) {
 
 		if message.Tag != tag {
 			continue
@@ -285,6 +383,9 @@ func RandomNumberReplacer(locale, entry, response, _ string) (string, string) {
 }
 
 func ChangeUserInformation(token string, changer func(Information) Information) {
+	userInformationMutex.Lock()
+	defer userInformationMutex.Unlock()
+
 	userInformation[token] = changer(userInformation[token])
 }
 
@@ -311,39 +412,14 @@ func GenresReplacer(locale, entry, response, token string) (string, string) {
 	return GenresTag, response
 }
 
+// LevenshteinDistance is kept for the callers of the former recursive
+// implementation.
 func LevenshteinDistance(first, second string) int {
-	if first == "" {
-		return len(second)
-	}
-	if second == "" {
-		return len(first)
-	}
-
-	if first[0] == second[0] {
-		return LevenshteinDistance(first[1:], second[1:])
-	}
-
-	a := LevenshteinDistance(first[1:], second[1:])
-	if b := LevenshteinDistance(first, second[1:]); a > b {
-		a = b
-	}
-
-	if c := LevenshteinDistance(first[1:], second); a > c {
-		a = c
-	}
-
-	return a + 1
+	return fuzzy.Levenshtein(first, second)
 }
 
 func LevenshteinContains(sentence, matching string, rate int) bool {
-	words := strings.Split(sentence, " ")
-	for _, word := range words {
-		if LevenshteinDistance(word, matching) <= rate {
-			return true
-		}
-	}
-
-	return false
+	return fuzzy.ContainsPhrase(sentence, matching, rate)
 }
 
 func SerializeMovies() (movies []Movie) {
@@ -374,9 +450,16 @@ func SerializeMovies() (movies []Movie) {
 	return
 }
 
+// FindMoviesGenres returns the english names of the genres in the content,
+// both being tokenized so that elisions such as "l'horreur" are split off.
+// The typos are tolerated within the fuzzy.Budget of the genre.
 func FindMoviesGenres(locale, content string) (output []string) {
+	content = strings.Join(Tokenize(locale, content), " ")
+
 	for i, genre := range MoviesGenres[locale] {
-		if LevenshteinContains(strings.ToUpper(content), strings.ToUpper(genre), 2) {
+		genre = strings.Join(Tokenize(locale, genre), " ")
+
+		if fuzzy.ContainsPhrase(content, genre, fuzzy.Budget(genre, 2)) {
 			output = append(output, MoviesGenres["en"][i])
 		}
 	}
@@ -384,6 +467,56 @@ func FindMoviesGenres(locale, content string) (output []string) {
 	return
 }
 
+// indexMovies maps the lowercase names of the movies to them, and builds the
+// tree of the names searched by FindMovie.
+func indexMovies(movies []Movie) (titles map[string]Movie, tree *fuzzy.BKTree) {
+	titles = map[string]Movie{}
+
+	var names []string
+	for _, movie := range movies {
+		name := strings.Join(fuzzy.Words(strings.ToLower(movie.Name)), " ")
+		if _, exists := titles[name]; exists || name == "" {
+			continue
+		}
+
+		titles[name] = movie
+		names = append(names, name)
+	}
+
+	return titles, fuzzy.NewBKTree(names)
+}
+
+// FindMovie returns the movie whose name is a run of words of the content,
+// within the fuzzy.Budget of the run. The most similar name is kept, and the
+// longest one when several are as similar.
+func FindMovie(content string) (movie Movie, found bool) {
+	words := fuzzy.Words(strings.ToLower(content))
+
+	var closest string
+	bestSimilarity := 0.0
+	for length := 1; length <= len(words); length++ {
+		for i := 0; i+length <= len(words); i++ {
+			phrase := strings.Join(words[i:i+length], " ")
+
+			name, exists := movieTree.Closest(phrase, fuzzy.Budget(phrase, 2))
+			if !exists {
+				continue
+			}
+
+			similarity := fuzzy.Similarity(phrase, name)
+			if similarity > bestSimilarity || (similarity == bestSimilarity && len(name) > len(closest)) {
+				closest, bestSimilarity = name, similarity
+			}
+		}
+	}
+
+	if closest == "" {
+		return Movie{}, false
+	}
+
+	return movieTitles[closest], true
+}
+
 func MovieSearchReplacer(locale, entry, response, token string) (string, string) {
 	genres := FindMoviesGenres(locale, entry)
 
@@ -461,11 +594,41 @@ func AdvicesReplacer(locale, entry, response, _ string) (string, string) {
 }
 
 func CacheIntents(locale string, _intents []Intent) {
+	intentsMutex.Lock()
+	defer intentsMutex.Unlock()
+
 	intents[locale] = _intents
 }
 
+// GetIntents returns the intents of the locale, serialized the first time
+// only.
+func GetIntents(locale string) []Intent {
+	intentsMutex.Lock()
+	_intents, exists := intents[locale]
+	intentsMutex.Unlock()
+
+	if !exists {
+		_intents = SerializeIntents(locale)
+	}
+
+	return _intents
+}
+
+// SerializeIntents reads the intents.json of the locale, or takes its
+// DefaultIntents when the file does not exist.
 func SerializeIntents(locale string) (_intents []Intent) {
-	err := json.Unmarshal(util.ReadFile(util.GetResDir("locales", "intents.json", locale)), &_intents)
+	bytes, err := os.ReadFile(util.GetResDir("locales", "intents.json", locale))
+	if errors.Is(err, os.ErrNotExist) && DefaultIntents[locale] != nil {
+		_intents = DefaultIntents[locale]
+		CacheIntents(locale, _intents)
+
+		return _intents
+	}
+	if err != nil {
+		bytes = util.ReadFile(util.GetResDir("locales", "intents.json", locale))
+	}
+
+	err = json.Unmarshal(bytes, &_intents)
 	if err != nil {
 		panic(err)
 	}
@@ -495,36 +658,37 @@ func SerializeModulesIntents(locale string) []Intent {
 	return intents
 }
 
-func removeStopWords(locale string, words []string) []string {
-	if len(words) <= 4 {
-		return words
-	}
-	stopWords := string(util.ReadFile(util.GetResDir("locales", "stopwords.txt", locale)))
-	var wordsToRemove []string
-	for _, stopWord := range strings.Split(stopWords, "\n") {
-		for _, word := range words {
-			if !strings.Contains(stopWord, word) {
-				continue
-			}
-			wordsToRemove = append(wordsToRemove, word)
+func GetTagByName(name string) string {
+	for _, locale := range Locales {
+		if locale.Name != name {
+			continue
 		}
+
+		return locale.Tag
 	}
-	return util.Difference(words, wordsToRemove)
+
+	return ""
 }
 
-func GetTagByName(name string) string {
+func GetNameByTag(tag string) string {
 	for _, locale := range Locales {
-		if locale.Name != name {
+		if locale.Tag != tag {
 			continue
 		}
 
-		return locale.Tag
+		return locale.Name
 	}
 
 	return ""
 }
 
 func Organize(locale string) (words, classes []string, documents []Document) {
+	return organize(locale, nil)
+}
+
+// organize analyzes the patterns with the pipeline, or DefaultPipeline when
+// it is nil.
+func organize(locale string, pipeline *Pipeline) (words, classes []string, documents []Document) {
 	intents := append(
 		SerializeIntents(locale),
 		SerializeModulesIntents(locale)...,
@@ -533,25 +697,19 @@ func Organize(locale string) (words, classes []string, documents []Document) {
 	for _, intent := range intents {
 		for _, pattern := range intent.Patterns {
 
-			patternSentence := Sentence{locale, pattern}
+			patternSentence := Sentence{Locale: locale, Content: pattern, Pipeline: pipeline}
 			patternSentence.arrange()
 
-			for _, word := range patternSentence.stem() {
-				if !util.Contains(words, word) {
-					words = append(words, word)
-				}
-			}
-
 			documents = append(documents, Document{
-				patternSentence,
-				intent.Tag,
+				Sentence: patternSentence,
+				Tag:      intent.Tag,
 			})
 		}
 
 		classes = append(classes, intent.Tag)
 	}
 
-	sort.Strings(words)
+	words = BuildVocabulary(documents).Words
 	sort.Strings(classes)
 
 	return words, classes, documents
@@ -560,11 +718,22 @@ func Organize(locale string) (words, classes []string, documents []Document) {
 func TrainData(locale string) (inputs, outputs [][]float64) {
 	words, classes, documents := Organize(locale)
 
+	return Vectorize(words, classes, BalanceDocuments(nil, documents, Balancing))
+}
+
+func Vectorize(words, classes []string, documents []Document) (inputs, outputs [][]float64) {
+	return VectorizeWith(NewVocabulary(words), classes, documents)
+}
+
+func VectorizeWith(vocabulary Vocabulary, classes []string, documents []Document) (inputs, outputs [][]float64) {
 	for _, document := range documents {
 		outputRow := make([]float64, len(classes))
-		bag := document.Sentence.WordsBag(words)
+		bag := vocabulary.Bag(document.Sentence)
 
 		outputRow[util.Index(classes, document.Tag)] = 1
+		for _, tag := range document.Tags {
+			outputRow[util.Index(classes, tag)] = 1
+		}
 
 		inputs = append(inputs, bag)
 		outputs = append(outputs, outputRow)
@@ -573,15 +742,118 @@ func TrainData(locale string) (inputs, outputs [][]float64) {
 	return inputs, outputs
 }
 
-func CreateNeuralNetwork(locale string, rate float64, hiddensNodes int) (neuralNetwork matrix.Network) {
+func TrainSequences(locale string) (words []string, sequences [][]int, outputs [][]float64) {
+	words, classes, documents := Organize(locale)
+	sequences, outputs = Sequences(words, classes, documents)
+
+	return words, sequences, outputs
+}
+
+func Sequences(words, classes []string, documents []Document) (sequences [][]int, outputs [][]float64) {
+	vocabulary := NewVocabulary(words)
+
+	for _, document := range documents {
+		outputRow := make([]float64, len(classes))
+		outputRow[util.Index(classes, document.Tag)] = 1
+
+		sequences = append(sequences, vocabulary.Sequence(document.Sentence))
+		outputs = append(outputs, outputRow)
+	}
+
+	return sequences, outputs
+}
+
+func CreateNeuralNetwork(locale string, rate float64, hiddensNodes, iterations int) (neuralNetwork matrix.Network, err error) {
 	tempDir := os.TempDir()
 	saveFile := filepath.Join(tempDir, "Marboris-Training.json")
 
-	inputs, outputs := TrainData(locale)
-	neuralNetwork = matrix.CreateNetwork(locale, rate, inputs, outputs, hiddensNodes)
-	neuralNetwork.Train(200)
+	model, documents := NewModel(locale, NetworkModel)
+	err = model.TrainIterations(documents, rate, hiddensNodes, iterations)
+	if err != nil {
+		return matrix.Network{}, err
+	}
+	neuralNetwork = *model.Network
 
 	neuralNetwork.Save(saveFile)
 
-	return
+	return neuralNetwork, nil
+}
+
+func CreateGRUClassifier(locale string, rate float64, hiddenNodes, iterations int) (gru matrix.GRU, err error) {
+	tempDir := os.TempDir()
+	saveFile := filepath.Join(tempDir, "Marboris-GRU.json")
+
+	model, documents := NewModel(locale, GRUModel)
+	err = model.TrainIterations(documents, rate, hiddenNodes, iterations)
+	if err != nil {
+		return matrix.GRU{}, err
+	}
+	gru = *model.GRU
+
+	gru.Save(saveFile)
+
+	return gru, nil
+}
+
+// CreateModel trains a model of the given type on the intents of the locale
+// and saves the bundle. When ValidationSplit is set, that fraction of the
+// documents is kept aside to calibrate the model and report its reliability.
+// With multiLabel, a sentence can trigger several intents, each class having
+// its own threshold.
+func CreateModel(locale, modelType string, rate float64, hiddensNodes int, multiLabel bool) (model Model, err error) {
+	model, documents := NewModel(locale, modelType)
+	model.MultiLabel = multiLabel
+	fmt.Print(ReportNormalization(documents, model.Pipeline))
+	training, validation := SplitDocuments(documents, ValidationSplit)
+
+	err = model.Train(training, rate, hiddensNodes)
+	if err != nil {
+		return Model{}, err
+	}
+
+	if model.MultiLabel {
+		validation = append(validation, CombineDocuments(nil, locale, validation, len(validation))...)
+		model.FitThresholds(validation)
+	} else {
+		model.CalibrateAndReport(validation)
+	}
+	model.ReportOutOfScope()
+
+	model.Save(ModelFile())
+
+	return model, nil
+}
+
+// SplitDocuments moves a fraction of the documents of every tag to the
+// validation set, always keeping at least one document of each tag to train.
+func SplitDocuments(documents []Document, fraction float64) (training, validation []Document) {
+	var tags []string
+	byTag := map[string][]Document{}
+	for _, document := range documents {
+		if _, exists := byTag[document.Tag]; !exists {
+			tags = append(tags, document.Tag)
+		}
+
+		byTag[document.Tag] = append(byTag[document.Tag], document)
+	}
+
+	for _, tag := range tags {
+		tagDocuments := byTag[tag]
+		rand.Shuffle(len(tagDocuments), func(i, j int) {
+			tagDocuments[i], tagDocuments[j] = tagDocuments[j], tagDocuments[i]
+		})
+
+		count := min(int(float64(len(tagDocuments))*fraction), len(tagDocuments)-1)
+		validation = append(validation, tagDocuments[:count]...)
+		training = append(training, tagDocuments[count:]...)
+	}
+
+	return training, validation
+}
+
+func TrainSequential(locale string, model *matrix.Sequential, iterations int) *matrix.Sequential {
+	inputs, outputs := TrainData(locale)
+	model.Train(inputs, outputs, iterations)
+
+	return model
 }
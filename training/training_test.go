@@ -0,0 +1,130 @@
+package training
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFindCountry(t *testing.T) {
+	saved := countries
+	defer func() { countries = saved }()
+
+	countries = []Country{
+		{Name: map[string]string{"en": "Chad"}, Code: "TD"},
+		{Name: map[string]string{"en": "Mali"}, Code: "ML"},
+		{Name: map[string]string{"en": "France"}, Code: "FR"},
+		{Name: map[string]string{"en": "Germany"}, Code: "DE"},
+	}
+
+	for sentence, code := range map[string]string{
+		"what is the capital of chad":    "TD",
+		"can we chat about something":    "",
+		"did you get my mail":            "",
+		"what is the area of Frnace":     "FR",
+		"what is the currency of germny": "DE",
+	} {
+		if country := FindCountry("en", sentence); country.Code != code {
+			t.Errorf("%q gave the country %q instead of %q", sentence, country.Code, code)
+		}
+	}
+}
+
+func TestFindMoviesGenres(t *testing.T) {
+	for content, genres := range map[string][]string{
+		"i like comdy movies":  {"Comedy"},
+		"show me some horor":   {"Horror"},
+		"i want a thriler one": {"Thriller"},
+		"an acton movie":       {"Action"},
+		// The budget grows with the length of the genre
+		"an akton movie":     nil,
+		"a docmentry please": {"Documentary"},
+		"i was at the bar":   nil,
+	} {
+		if found := FindMoviesGenres("en", content); !reflect.DeepEqual(found, genres) {
+			t.Errorf("%q gave the genres %q instead of %q", content, found, genres)
+		}
+	}
+}
+
+func TestFindMovie(t *testing.T) {
+	savedTitles, savedTree := movieTitles, movieTree
+	defer func() { movieTitles, movieTree = savedTitles, savedTree }()
+
+	movieTitles, movieTree = indexMovies([]Movie{
+		{Name: "Toy Story"},
+		{Name: "Toy Story 2"},
+		{Name: "Heat"},
+		{Name: "Up"},
+		{Name: "The Shawshank Redemption"},
+	})
+
+	for content, name := range map[string]string{
+		"i loved toy story":                  "Toy Story",
+		"have you seen toy story 2":          "Toy Story 2",
+		"what about the shawshenk redemtion": "The Shawshank Redemption",
+		"i watched heat yesterday":           "Heat",
+		"i watched haet yesterday":           "Heat",
+		"what is up":                         "Up",
+		"what is the weather":                "",
+	} {
+		movie, found := FindMovie(content)
+		if movie.Name != name || found != (name != "") {
+			t.Errorf("%q gave the movie %q instead of %q", content, movie.Name, name)
+		}
+	}
+}
+
+func TestFindSlotName(t *testing.T) {
+	for sentence, name := range map[string]string{
+		"اسم من سارا":          "سارا",
+		"اسمم سارا است":        "سارا",
+		"سلام، نام من علي است": "علی",
+		"اسم من":               "",
+		"سلام":                 "",
+	} {
+		if found := FindSlotName("fa", sentence); found != name {
+			t.Errorf("%q gave the name %q instead of %q", sentence, found, name)
+		}
+	}
+}
+
+func TestPersianNameSetter(t *testing.T) {
+	token := "persian name setter"
+	tag, response := NameSetterReplacer("fa", "اسم من سارا", "عالی! سلام %s", token)
+	if tag != NameSetterTag || response != "عالی! سلام سارا" {
+		t.Fatalf("the name was answered with %q: %q", tag, response)
+	}
+
+	if name := GetUserInformation(token).Name; name != "سارا" {
+		t.Errorf("the name %q was saved instead of %q", name, "سارا")
+	}
+}
+
+func TestConcurrentResponses(t *testing.T) {
+	var group sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			for _, locale := range []string{"fr", "de", "es"} {
+				if _, response := Respond(locale, OutOfScopeTag, "", ""); response == "" {
+					t.Errorf("the %s out-of-scope message is empty", locale)
+				}
+
+				if tag, _ := Respond(locale, "thanks", "", ""); tag != "thanks" {
+					t.Errorf("the %s thanks were answered as %q", locale, tag)
+				}
+			}
+		}()
+	}
+
+	group.Wait()
+}
+
+func TestSerializeOutOfScopeMissing(t *testing.T) {
+	if _, err := SerializeOutOfScope("missing"); err == nil {
+		t.Error("the out-of-scope sentences of a missing locale were read")
+	}
+}
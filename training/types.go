@@ -1,5 +1,10 @@
 package training
 
+import (
+	"marboris/nout/fuzzy"
+	matrix "marboris/nout/matrix"
+)
+
 type Country struct {
 	Name     map[string]string `json:"name"`
 	Capital  string            `json:"capital"`
@@ -50,14 +55,183 @@ type Intent struct {
 type Sentence struct {
 	Locale  string
 	Content string
+	// Pipeline replaces DefaultPipeline when set.
+	Pipeline *Pipeline `json:"-"`
+}
+
+type Pipeline struct {
+	Stages    []string         `json:"stages,omitempty"`
+	StopWords StopWordSettings `json:"stop_words"`
+	NGrams    NGramSettings    `json:"ngrams"`
+	Spelling  SpellingSettings `json:"spelling"`
+}
+
+// SpellingSettings correct the tokens that are not among Words, the tokens
+// of the training patterns.
+type SpellingSettings struct {
+	MaxDistance int      `json:"max_distance,omitempty"`
+	Words       []string `json:"words,omitempty"`
+	tree        *fuzzy.BKTree
+}
+
+// NGramSettings enable the word n-grams from WordMin to WordMax stems and
+// the character n-grams from CharMin to CharMax runes.
+type NGramSettings struct {
+	WordMin      int `json:"word_min,omitempty"`
+	WordMax      int `json:"word_max,omitempty"`
+	CharMin      int `json:"char_min,omitempty"`
+	CharMax      int `json:"char_max,omitempty"`
+	MinFrequency int `json:"min_frequency,omitempty"`
+	MaxNGrams    int `json:"max_ngrams,omitempty"`
+}
+
+type StopWordSettings struct {
+	Disabled bool     `json:"disabled,omitempty"`
+	Extra    []string `json:"extra,omitempty"`
+	Kept     []string `json:"kept,omitempty"`
 }
 
 type Document struct {
 	Sentence Sentence
 	Tag      string
+	// Tags are the other intents of the sentence in multi-label mode.
+	Tags []string
 }
 
 type Locale struct {
 	Tag  string
 	Name string
 }
+
+type Prediction struct {
+	Tag   string  `json:"tag"`
+	Score float64 `json:"score"`
+}
+
+type Threshold struct {
+	Confidence float64 `json:"confidence"`
+	Margin     float64 `json:"margin"`
+}
+
+type Model struct {
+	Locale      string                     `json:"locale"`
+	Type        string                     `json:"type"`
+	Words       []string                   `json:"words"`
+	Classes     []string                   `json:"classes"`
+	Network     *matrix.Network            `json:"network,omitempty"`
+	GRU         *matrix.GRU                `json:"gru,omitempty"`
+	NaiveBayes  *matrix.NaiveBayes         `json:"naive_bayes,omitempty"`
+	Logistic    *matrix.LogisticRegression `json:"logistic,omitempty"`
+	KNN         *matrix.KNN                `json:"knn,omitempty"`
+	MultiLabel  bool                       `json:"multi_label,omitempty"`
+	Thresholds  []float64                  `json:"thresholds,omitempty"`
+	Patterns    []Document                 `json:"patterns,omitempty"`
+	Embedding   []matrix.Matrix            `json:"embedding,omitempty"`
+	Pooling     string                     `json:"pooling,omitempty"`
+	Temperature float64                    `json:"temperature,omitempty"`
+	Threshold   Threshold                  `json:"threshold"`
+	Pipeline    Pipeline                   `json:"pipeline"`
+	Vectorizer  Vectorizer                 `json:"vectorizer"`
+	// random draws the weights and samples while training, from the global
+	// source when it is nil.
+	random *matrix.Random
+}
+
+type OutOfScopeReport struct {
+	Total           int            `json:"total"`
+	FalseAccepts    int            `json:"false_accepts"`
+	FalseAcceptRate float64        `json:"false_accept_rate"`
+	AcceptedTags    map[string]int `json:"accepted_tags"`
+}
+
+type Clarification struct {
+	Content     string       `json:"content"`
+	Predictions []Prediction `json:"predictions"`
+}
+
+type ReliabilityBucket struct {
+	Lower      float64 `json:"lower"`
+	Upper      float64 `json:"upper"`
+	Count      int     `json:"count"`
+	Accuracy   float64 `json:"accuracy"`
+	Confidence float64 `json:"confidence"`
+}
+
+type ReliabilityReport struct {
+	ExpectedCalibrationError float64             `json:"expected_calibration_error"`
+	Buckets                  []ReliabilityBucket `json:"buckets"`
+}
+
+type TokenContribution struct {
+	Token        string  `json:"token"`
+	Stem         string  `json:"stem"`
+	Contribution float64 `json:"contribution"`
+}
+
+type Explanation struct {
+	Tag    string              `json:"tag"`
+	Score  float64             `json:"score"`
+	Tokens []TokenContribution `json:"tokens"`
+}
+
+type FineTuneReport struct {
+	NewWords          []string `json:"new_words"`
+	NewClasses        []string `json:"new_classes"`
+	OldAccuracyBefore float64  `json:"old_accuracy_before"`
+	OldAccuracyAfter  float64  `json:"old_accuracy_after"`
+	Forgetting        bool     `json:"forgetting"`
+}
+
+type TagCount struct {
+	Tag    string `json:"tag"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+type DatasetReport struct {
+	Tags []TagCount `json:"tags"`
+}
+
+type EnsembleMember struct {
+	Type        string  `json:"type"`
+	Rate        float64 `json:"rate"`
+	HiddenNodes int     `json:"hidden_nodes"`
+	Seed        int64   `json:"seed"`
+}
+
+type Ensemble struct {
+	Locale    string           `json:"locale"`
+	Classes   []string         `json:"classes"`
+	Voting    string           `json:"voting"`
+	Members   []EnsembleMember `json:"members"`
+	Models    []Model          `json:"models"`
+	Threshold Threshold        `json:"threshold"`
+}
+
+type EnsembleReport struct {
+	Accuracy         float64   `json:"accuracy"`
+	MemberAccuracies []float64 `json:"member_accuracies"`
+}
+
+type NearestPattern struct {
+	Pattern    string  `json:"pattern"`
+	Tag        string  `json:"tag"`
+	Similarity float64 `json:"similarity"`
+}
+
+// NormalizationReport gives the vocabulary entries replaced by the
+// dictionary, and the ones only its replacements bring.
+type NormalizationReport struct {
+	Before    int      `json:"before"`
+	After     int      `json:"after"`
+	Collapsed []string `json:"collapsed"`
+	Added     []string `json:"added"`
+}
+
+// Vectorizer is the kind of vectors fed to the models and the state fitted
+// on the training documents.
+type Vectorizer struct {
+	Type       string    `json:"type,omitempty"`
+	IDF        []float64 `json:"idf,omitempty"`
+	Dimensions int       `json:"dimensions,omitempty"`
+}
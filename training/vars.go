@@ -1,12 +1,20 @@
 package training
 
+import (
+	"sync"
+
+	matrix "marboris/nout/matrix"
+)
+
 var (
 	CapitalTag  = "capital"
 	AreaTag     = "area"
 	JokesTag    = "jokes"
 	CurrencyTag = "currency"
 	countries   = SerializeCountries()
-	messages    = map[string][]Message{}
+
+	messagesMutex = sync.Mutex{}
+	messages      = map[string][]Message{}
 
 	GenresTag = "movies genres"
 
@@ -14,8 +22,9 @@ var (
 
 	MoviesAlreadyTag = "already seen movie"
 
-	MoviesDataTag   = "movies search from data"
-	userInformation = map[string]Information{}
+	MoviesDataTag        = "movies search from data"
+	userInformationMutex = sync.Mutex{}
+	userInformation      = map[string]Information{}
 
 	MoviesGenres = map[string][]string{
 		"en": {
@@ -25,11 +34,24 @@ var (
 	}
 	movies = SerializeMovies()
 
+	movieTitles, movieTree = indexMovies(movies)
+
 	ArticleCountriesm = map[string]func(string) string{}
 
+	// CountryNames give the names of the countries, by code, in the locales
+	// missing from the dataset.
+	CountryNames = map[string]map[string]string{}
+
+	// DefaultIntents, DefaultMessages and DefaultStopWords are used for the
+	// locales without the matching file in the res directory.
+	DefaultIntents   = map[string][]Intent{}
+	DefaultMessages  = map[string][]Message{}
+	DefaultStopWords = map[string][]string{}
+
 	modulesm = map[string][]Modulem{}
 
-	intents = map[string][]Intent{}
+	intentsMutex = sync.Mutex{}
+	intents      = map[string][]Intent{}
 
 	Locales = []Locale{
 		{
@@ -44,6 +66,10 @@ var (
 
 	NameSetterTag = "name setter"
 
+	// NameSlots give the words after which the name is said, for the locales
+	// whose names are not in the names dataset.
+	NameSlots = map[string][]string{}
+
 	MathDecimals = map[string]string{
 		"en": `(\d+( |-)decimal(s)?)|(number (of )?decimal(s)? (is )?\d+)`,
 	}
@@ -56,6 +82,166 @@ var (
 
 // ----------------------------------------------------------
 
+const (
+	NetworkModel    = "network"
+	GRUModel        = "gru"
+	EmbeddingModel  = "embedding"
+	NaiveBayesModel = "naivebayes"
+	LogisticModel   = "logistic"
+	KNNModel        = "knn"
+)
+
+var (
+	NetworkIterations   = 200
+	GRUIterations       = 50
+	EmbeddingIterations = 200
+
+	GRUDimensions = 32
+
+	NaiveBayesSmoothing    = 1.0
+	LogisticIterations     = 300
+	LogisticRegularization = 0.001
+	KNNNeighbors           = 3
+
+	EmbeddingDimensions = 50
+	EmbeddingPooling    = matrix.MeanPooling
+	// EmbeddingVectors maps a locale to a file of pre-trained vectors in the
+	// GloVe format.
+	EmbeddingVectors = map[string]string{}
+	// FreezeEmbeddings keeps the pre-trained vectors from being updated,
+	// the other words still learning theirs.
+	FreezeEmbeddings = false
+
+	FineTuneIterations = 50
+	// ForgettingTolerance is how much the accuracy on the previous classes
+	// may drop while fine-tuning before it is reported as forgetting.
+	ForgettingTolerance = 0.05
+
+	// ValidationSplit is the fraction of the documents of every tag kept
+	// aside to calibrate the models, half to fit the temperature and half to
+	// report the reliability. Calibration is opt-in: models train on every
+	// document and stay uncalibrated when it is 0, the default.
+	ValidationSplit    = 0.0
+	ReliabilityBuckets = 10
+	// ComparisonSplit is the fraction of the documents CompareModels
+	// evaluates the models on.
+	ComparisonSplit = 0.2
+
+	DefaultThreshold = Threshold{
+		Confidence: 0.4,
+		Margin:     0.05,
+	}
+)
+
+var (
+	ClarificationTag    = "clarification"
+	clarificationsMutex = sync.Mutex{}
+	clarifications      = map[string]Clarification{}
+
+	// ClarificationOrdinals are the words that pick the first or the second
+	// intent when answering a clarification.
+	ClarificationOrdinals = map[string][][]string{
+		"en": {
+			{"first", "1", "former"},
+			{"second", "2", "latter"},
+		},
+	}
+)
+
+const (
+	NoBalancing   = ""
+	Oversampling  = "oversample"
+	Undersampling = "undersample"
+)
+
+var (
+	// Balancing resamples the training documents so that every tag has as
+	// many patterns as the largest, with Oversampling, or the smallest, with
+	// Undersampling.
+	Balancing = NoBalancing
+	// ClassWeighting weights every document by the inverse frequency of its
+	// tag: in the loss of the networks and the logistic regression, the
+	// counts of naive Bayes and the votes of KNN.
+	ClassWeighting = false
+)
+
+var (
+	// DefaultPipeline analyzes the patterns while training, and is saved with
+	// the model to analyze the sentences the same way at inference.
+	DefaultPipeline = Pipeline{
+		Stages: DefaultStages,
+		NGrams: NGramSettings{
+			MinFrequency: 2,
+			MaxNGrams:    1000,
+		},
+	}
+)
+
+const (
+	BinaryVectorizer  = ""
+	TFVectorizer      = "tf"
+	TFIDFVectorizer   = "tfidf"
+	HashingVectorizer = "hashing"
+)
+
+var (
+	DefaultVectorizer = BinaryVectorizer
+	// HashingDimensions is the size of the vectors of HashingVectorizer,
+	// which never changes whatever the words.
+	HashingDimensions = 1024
+	// StemCacheSize is how many stems of each language are cached at most.
+	StemCacheSize = 10000
+)
+
+var (
+	// SpellingDistances is the edit distance budget of the spelling
+	// correction of every locale, which is disabled for the others.
+	SpellingDistances = map[string]int{
+		"en": 2,
+	}
+)
+
+var (
+	// Normalizations expand the contractions and informal spellings of a
+	// locale without a normalization.json.
+	Normalizations = map[string]map[string]string{
+		"en": {
+			"what's": "what is", "whats": "what is", "where's": "where is", "who's": "who is",
+			"how's": "how is", "it's": "it is", "that's": "that is", "there's": "there is",
+			"i'm": "i am", "im": "i am", "you're": "you are", "youre": "you are", "we're": "we are",
+			"they're": "they are", "i've": "i have", "you've": "you have", "i'll": "i will",
+			"you'll": "you will", "i'd": "i would", "you'd": "you would",
+			"don't": "do not", "dont": "do not", "doesn't": "does not", "doesnt": "does not",
+			"didn't": "did not", "can't": "can not", "cant": "can not", "cannot": "can not",
+			"won't": "will not", "isn't": "is not", "aren't": "are not", "wasn't": "was not",
+			"let's": "let us", "gimme": "give me", "lemme": "let me", "wanna": "want to",
+			"gonna": "going to", "gotta": "got to", "dunno": "do not know",
+			"u": "you", "ur": "your", "pls": "please", "plz": "please",
+			"thx": "thanks", "thanx": "thanks", "ty": "thank you", "bday": "birthday",
+		},
+	}
+)
+
+var (
+	// MultiLabelCombinations is how many sentences joining the patterns of
+	// two intents are added to the training documents.
+	MultiLabelCombinations = 200
+	LabelThreshold         = 0.5
+
+	Conjunctions = map[string]string{
+		"en": "and",
+	}
+)
+
+const (
+	AverageVoting  = "average"
+	MajorityVoting = "majority"
+)
+
+// OutOfScopeTag is given to sentences that match no intent, and its message
+// is the fallback answer.
+const OutOfScopeTag = "don't understand"
+
 const (
 	jokeURL   = "https://official-joke-api.appspot.com/random_joke"
 	adviceURL = "https://api.adviceslip.com/advice"
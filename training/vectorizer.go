@@ -0,0 +1,134 @@
+package training
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// Size is the length of the vectors of the vocabulary.
+func (vocabulary Vocabulary) Size() int {
+	if vocabulary.Vectorizer.Type == HashingVectorizer {
+		return vocabulary.Vectorizer.dimensions()
+	}
+
+	return len(vocabulary.Words)
+}
+
+// dimensions returns the size of the hashed vectors, HashingDimensions when
+// it is not set.
+func (vectorizer Vectorizer) dimensions() int {
+	if vectorizer.Dimensions == 0 {
+		return HashingDimensions
+	}
+
+	return vectorizer.Dimensions
+}
+
+// Validate checks that the vectorizer can vectorize sentences.
+func (vectorizer Vectorizer) Validate() error {
+	switch vectorizer.Type {
+	case BinaryVectorizer, TFVectorizer, TFIDFVectorizer:
+	case HashingVectorizer:
+		if vectorizer.dimensions() < 1 {
+			return fmt.Errorf("the hashing vectorizer needs positive dimensions, not %d", vectorizer.Dimensions)
+		}
+	default:
+		return fmt.Errorf("unknown vectorizer %q", vectorizer.Type)
+	}
+
+	return nil
+}
+
+// Fit computes the inverse document frequency of every word of the
+// vocabulary from the documents, smoothed so that no word gets zero. Only
+// TFIDFVectorizer has something to fit.
+func (vectorizer *Vectorizer) Fit(words []string, documents []Document) {
+	if vectorizer.Type != TFIDFVectorizer {
+		vectorizer.IDF = nil
+		return
+	}
+
+	vocabulary := NewVocabulary(words)
+
+	frequencies := make([]float64, len(words))
+	for _, document := range documents {
+		counted := map[int]bool{}
+		for _, word := range document.Sentence.features() {
+			i, exists := vocabulary.index[word]
+			if exists && !counted[i] {
+				counted[i] = true
+				frequencies[i]++
+			}
+		}
+	}
+
+	vectorizer.IDF = make([]float64, len(words))
+	for i, frequency := range frequencies {
+		vectorizer.IDF[i] = math.Log((1+float64(len(documents)))/(1+frequency)) + 1
+	}
+}
+
+// hash returns the index of the word in a vector of the given dimensions.
+func hash(word string, dimensions int) int {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(word))
+
+	return int(hasher.Sum32() % uint32(dimensions))
+}
+
+// count returns how many times every word of the vocabulary, or every
+// hashed index, appears in the features, and the number of features.
+func (vocabulary Vocabulary) count(features []string) (counts []float64, total float64) {
+	counts = make([]float64, vocabulary.Size())
+	for _, word := range features {
+		if vocabulary.Vectorizer.Type == HashingVectorizer {
+			counts[hash(word, vocabulary.Vectorizer.dimensions())]++
+			total++
+			continue
+		}
+
+		if i, exists := vocabulary.index[word]; exists {
+			counts[i]++
+			total++
+		}
+	}
+
+	return
+}
+
+// Bag vectorizes the stems and n-grams of the sentence: their presence with
+// BinaryVectorizer and HashingVectorizer, their frequency with TFVectorizer,
+// or their frequency weighted by the IDF and normalized with TFIDFVectorizer.
+func (vocabulary Vocabulary) Bag(sentence Sentence) []float64 {
+	bag, total := vocabulary.count(sentence.features())
+
+	switch vocabulary.Vectorizer.Type {
+	case TFVectorizer, TFIDFVectorizer:
+		if total == 0 {
+			return bag
+		}
+
+		var norm float64
+		for i := range bag {
+			bag[i] /= total
+			if vocabulary.Vectorizer.Type == TFIDFVectorizer && i < len(vocabulary.Vectorizer.IDF) {
+				bag[i] *= vocabulary.Vectorizer.IDF[i]
+			}
+
+			norm += bag[i] * bag[i]
+		}
+
+		if vocabulary.Vectorizer.Type == TFIDFVectorizer {
+			for i := range bag {
+				bag[i] /= math.Sqrt(norm)
+			}
+		}
+	default:
+		for i, count := range bag {
+			bag[i] = math.Min(count, 1)
+		}
+	}
+
+	return bag
+}
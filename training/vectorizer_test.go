@@ -0,0 +1,45 @@
+package training
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHashingDimensionsRoundTrip(t *testing.T) {
+	bytes, err := json.Marshal(Vectorizer{Type: HashingVectorizer})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vectorizer Vectorizer
+	err = json.Unmarshal(bytes, &vectorizer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := vectorizer.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	vocabulary := NewVocabulary(nil)
+	vocabulary.Vectorizer = vectorizer
+	if vocabulary.Size() != HashingDimensions {
+		t.Fatalf("the vectors have %d values instead of %d", vocabulary.Size(), HashingDimensions)
+	}
+
+	counts, total := vocabulary.count([]string{"hello", "world", "hello"})
+	if len(counts) != HashingDimensions || total != 3 {
+		t.Fatalf("%d features were counted in %d values", int(total), len(counts))
+	}
+}
+
+func TestVectorizerValidate(t *testing.T) {
+	for _, vectorizer := range []Vectorizer{
+		{Type: HashingVectorizer, Dimensions: -1},
+		{Type: "bogus"},
+	} {
+		if vectorizer.Validate() == nil {
+			t.Errorf("%+v is valid", vectorizer)
+		}
+	}
+}
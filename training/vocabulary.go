@@ -0,0 +1,141 @@
+package training
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/tebeka/snowball"
+)
+
+var (
+	// Stemmers are not safe for concurrent use, so a single mutex guards them
+	// and the stems they already produced.
+	stemMutex = sync.Mutex{}
+	stemmers  = map[string]*snowball.Stemmer{}
+	stemCache = map[string]map[string]string{}
+)
+
+// stemWords stems every word with the snowball stemmer of the language,
+// created once and reused, caching the stems. The cache of a language is
+// emptied once it holds StemCacheSize stems, so that the sentences of a
+// long running server do not grow it forever.
+func stemWords(language string, words []string) ([]string, error) {
+	stemMutex.Lock()
+	defer stemMutex.Unlock()
+
+	stemmer, exists := stemmers[language]
+	if !exists {
+		var err error
+		stemmer, err = snowball.New(language)
+		if err != nil {
+			return nil, err
+		}
+
+		stemmers[language] = stemmer
+		stemCache[language] = map[string]string{}
+	}
+
+	cache := stemCache[language]
+	stems := make([]string, len(words))
+	for i, word := range words {
+		stem, exists := cache[word]
+		if !exists {
+			stem = stemmer.Stem(word)
+			if len(cache) >= StemCacheSize {
+				cache = map[string]string{}
+				stemCache[language] = cache
+			}
+			cache[word] = stem
+		}
+
+		stems[i] = stem
+	}
+
+	return stems, nil
+}
+
+// Vocabulary indexes the words of a model, so that a sentence is stemmed
+// once and each of its stems looked up instead of scanning every word.
+type Vocabulary struct {
+	Words      []string
+	Vectorizer Vectorizer
+	index      map[string]int
+}
+
+func NewVocabulary(words []string) Vocabulary {
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		if _, exists := index[word]; !exists {
+			index[word] = i
+		}
+	}
+
+	return Vocabulary{
+		Words: words,
+		index: index,
+	}
+}
+
+// BuildVocabulary collects the sorted stems of every document, and the
+// n-grams enabled by their pipeline that appear in at least MinFrequency
+// documents, MaxNGrams of them at most, the most frequent first.
+func BuildVocabulary(documents []Document) Vocabulary {
+	settings := DefaultPipeline.NGrams
+	if len(documents) > 0 {
+		settings = documents[0].Sentence.pipeline().NGrams
+	}
+
+	seen := map[string]bool{}
+	var words []string
+	frequencies := map[string]int{}
+	for _, document := range documents {
+		stems := document.Sentence.stem()
+		for _, word := range stems {
+			if !seen[word] {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+
+		counted := map[string]bool{}
+		for _, ngram := range document.Sentence.ngrams(stems) {
+			if !counted[ngram] {
+				counted[ngram] = true
+				frequencies[ngram]++
+			}
+		}
+	}
+
+	var ngrams []string
+	for ngram, frequency := range frequencies {
+		if frequency >= settings.MinFrequency && !seen[ngram] {
+			ngrams = append(ngrams, ngram)
+		}
+	}
+
+	sort.Slice(ngrams, func(i, j int) bool {
+		if frequencies[ngrams[i]] != frequencies[ngrams[j]] {
+			return frequencies[ngrams[i]] > frequencies[ngrams[j]]
+		}
+
+		return ngrams[i] < ngrams[j]
+	})
+	if settings.MaxNGrams > 0 && len(ngrams) > settings.MaxNGrams {
+		ngrams = ngrams[:settings.MaxNGrams]
+	}
+
+	words = append(words, ngrams...)
+	sort.Strings(words)
+
+	return NewVocabulary(words)
+}
+
+func (vocabulary Vocabulary) Sequence(sentence Sentence) (ids []int) {
+	for _, word := range sentence.stem() {
+		if i, exists := vocabulary.index[word]; exists {
+			ids = append(ids, i)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,210 @@
+package training
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tebeka/snowball"
+	util "marboris/nout/utils"
+)
+
+var syllables = []string{"ka", "lo", "mi", "ne", "ru", "sa", "ti", "vo", "de", "pa", "ge", "fi"}
+
+// generateDocuments returns patterns of three to six random words, spread
+// over tags, so that the longer ones go through the stop words.
+func generateDocuments(random *rand.Rand, count, tags, vocabulary int) (documents []Document) {
+	pool := make([]string, vocabulary)
+	for i := range pool {
+		var word strings.Builder
+		for j := 0; j < 3+random.Intn(2); j++ {
+			word.WriteString(syllables[random.Intn(len(syllables))])
+		}
+
+		pool[i] = word.String()
+	}
+
+	for i := 0; i < count; i++ {
+		words := make([]string, 3+random.Intn(4))
+		for j := range words {
+			words[j] = pool[random.Intn(len(pool))]
+		}
+
+		documents = append(documents, Document{
+			Sentence: Sentence{Locale: "en", Content: strings.Join(words, " ")},
+			Tag:      fmt.Sprintf("tag %d", i%tags),
+		})
+	}
+
+	return
+}
+
+// legacyStopWords are written to a file that the legacy path reads, so that
+// it runs without the res directory.
+var legacyStopWords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "by", "for", "from", "has", "he", "in", "is", "it",
+	"its", "of", "on", "that", "the", "to", "was", "were", "will", "with",
+}
+
+func writeLegacyStopWords(tb testing.TB) string {
+	fileName := filepath.Join(tb.TempDir(), "stopwords.txt")
+	err := os.WriteFile(fileName, []byte(strings.Join(legacyStopWords, "\n")), 0o644)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return fileName
+}
+
+// legacyRemoveStopWords, legacyStem and legacyVectorize are the code path
+// used before the vocabulary index: the stop words file is read and a
+// stemmer is created for every sentence, and the words are searched
+// linearly.
+func legacyRemoveStopWords(stopWordsFile string, words []string) []string {
+	if len(words) <= 4 {
+		return words
+	}
+	stopWords := string(util.ReadFile(stopWordsFile))
+	var wordsToRemove []string
+	for _, stopWord := range strings.Split(stopWords, "\n") {
+		for _, word := range words {
+			if !strings.Contains(stopWord, word) {
+				continue
+			}
+			wordsToRemove = append(wordsToRemove, word)
+		}
+	}
+	return util.Difference(words, wordsToRemove)
+}
+
+func legacyStem(stopWordsFile string, sentence Sentence) (tokenizeWords []string) {
+	tokens := strings.Fields(sentence.Content)
+	for i, token := range tokens {
+		tokens[i] = strings.ToLower(token)
+	}
+	tokens = legacyRemoveStopWords(stopWordsFile, tokens)
+
+	stemmer, err := snowball.New(stemmerLanguage(sentence.Locale))
+	if err != nil {
+		return
+	}
+
+	for _, tokenizeWord := range tokens {
+		tokenizeWords = append(tokenizeWords, stemmer.Stem(tokenizeWord))
+	}
+
+	return
+}
+
+func legacyVectorize(stopWordsFile string, documents []Document) (words []string, inputs [][]float64) {
+	for _, document := range documents {
+		for _, word := range legacyStem(stopWordsFile, document.Sentence) {
+			if !util.Contains(words, word) {
+				words = append(words, word)
+			}
+		}
+	}
+	sort.Strings(words)
+
+	for _, document := range documents {
+		var bag []float64
+		for _, word := range words {
+			var value float64
+			if util.Contains(legacyStem(stopWordsFile, document.Sentence), word) {
+				value = 1
+			}
+
+			bag = append(bag, value)
+		}
+
+		inputs = append(inputs, bag)
+	}
+
+	return
+}
+
+func cachedVectorize(documents []Document) (words []string, inputs [][]float64) {
+	vocabulary := BuildVocabulary(documents)
+	for _, document := range documents {
+		inputs = append(inputs, vocabulary.Bag(document.Sentence))
+	}
+
+	return vocabulary.Words, inputs
+}
+
+// TestLegacyVectors checks that the vocabulary index produces the same
+// words and vectors as the legacy path.
+func TestLegacyVectors(t *testing.T) {
+	documents := generateDocuments(rand.New(rand.NewSource(1)), 300, 10, 100)
+
+	legacyWords, legacyInputs := legacyVectorize(writeLegacyStopWords(t), documents)
+	words, inputs := cachedVectorize(documents)
+
+	if !reflect.DeepEqual(legacyWords, words) {
+		t.Fatalf("the legacy path has %d words and the cached one %d", len(legacyWords), len(words))
+	}
+
+	for i := range inputs {
+		for j := range inputs[i] {
+			if legacyInputs[i][j] != inputs[i][j] {
+				t.Fatalf(
+					"the value of %q for %q is %g instead of %g",
+					words[j], documents[i].Sentence.Content, inputs[i][j], legacyInputs[i][j],
+				)
+			}
+		}
+	}
+}
+
+func benchmarkDocuments() []Document {
+	return generateDocuments(rand.New(rand.NewSource(1)), 5000, 50, 1000)
+}
+
+func BenchmarkSentenceLegacy(b *testing.B) {
+	stopWordsFile := writeLegacyStopWords(b)
+	documents := benchmarkDocuments()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		legacyVectorize(stopWordsFile, documents)
+	}
+}
+
+func BenchmarkSentenceCached(b *testing.B) {
+	documents := benchmarkDocuments()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cachedVectorize(documents)
+	}
+}
+
+func TestStemCacheSize(t *testing.T) {
+	size := StemCacheSize
+	StemCacheSize = 3
+	defer func() { StemCacheSize = size }()
+
+	stemMutex.Lock()
+	stemCache["english"] = map[string]string{}
+	stemMutex.Unlock()
+
+	for _, word := range []string{"running", "jumps", "walked", "talking", "played"} {
+		stems, err := stemWords("english", []string{word})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stemCache["english"]) > StemCacheSize {
+			t.Fatalf("%d stems are cached after %q", len(stemCache["english"]), word)
+		}
+
+		if expected, _ := snowball.New("english"); stems[0] != expected.Stem(word) {
+			t.Errorf("%q was stemmed as %q", word, stems[0])
+		}
+	}
+}